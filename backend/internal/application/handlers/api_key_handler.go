@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+	logger        *zap.Logger
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"` // only returned once
+}
+
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey creates an API key for the authenticated user
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	identity := services.IdentityFromContext(c.Request.Context())
+	key, plaintext, err := h.apiKeyService.CreateKey(ctx, identity.User.ID, req.Name, req.Scopes)
+	if err != nil {
+		h.logger.Error("Failed to create API key", zap.String("user_id", identity.UserID()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("API key created",
+		zap.String("user_id", identity.UserID()),
+		zap.String("key_id", key.ID.Hex()),
+		zap.Strings("scopes", key.Scopes))
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		APIKeyResponse: h.convertToAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+// GetAPIKeys lists the API keys of the authenticated user
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	identity := services.IdentityFromContext(c.Request.Context())
+	keys, err := h.apiKeyService.ListKeys(ctx, identity.User.ID)
+	if err != nil {
+		h.logger.Error("Failed to list API keys", zap.String("user_id", identity.UserID()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API keys"})
+		return
+	}
+
+	response := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		response[i] = h.convertToAPIKeyResponse(key)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": response})
+}
+
+// RevokeAPIKey revokes one of the authenticated user's API keys
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	keyID, err := primitive.ObjectIDFromHex(c.Param("keyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	identity := services.IdentityFromContext(c.Request.Context())
+	if err := h.apiKeyService.RevokeKey(ctx, identity.User.ID, keyID); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("API key revoked", zap.String("user_id", identity.UserID()), zap.String("key_id", keyID.Hex()))
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) convertToAPIKeyResponse(key *entities.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID.Hex(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type AuthHandler struct {
+	authService *services.AuthService
+	logger      *zap.Logger
+}
+
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type LoginRequest struct {
+	Login    string `json:"login" binding:"required"` // username or email
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type UserResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Email    string `json:"email,omitempty"`
+	Plan     string `json:"plan,omitempty"`
+	// HistoryPaused is only shown to the user themselves
+	HistoryPaused *bool     `json:"history_paused,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TokenResponse struct {
+	AccessToken           string       `json:"access_token"`
+	TokenType             string       `json:"token_type"`
+	ExpiresAt             time.Time    `json:"expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  UserResponse `json:"user"`
+}
+
+func NewAuthHandler(authService *services.AuthService, logger *zap.Logger) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// Register creates a new user account
+func (h *AuthHandler) Register(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, err := h.authService.Register(ctx, req.Username, req.Email, req.Password)
+	if err != nil {
+		h.logger.Error("Failed to register user", zap.String("username", req.Username), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("User registered", zap.String("user_id", user.ID.Hex()))
+
+	c.JSON(http.StatusCreated, h.convertToUserResponse(user, true))
+}
+
+// Login exchanges credentials for an access and refresh token
+func (h *AuthHandler) Login(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	tokens, err := h.authService.Login(ctx, req.Login, req.Password)
+	if err != nil {
+		h.logger.Warn("Login failed", zap.String("login", req.Login), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Invalid username or password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.convertToTokenResponse(tokens))
+}
+
+// Refresh rotates a refresh token and issues a new access token
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	tokens, err := h.authService.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		h.logger.Warn("Token refresh failed", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.convertToTokenResponse(tokens))
+}
+
+// Logout revokes a refresh token
+func (h *AuthHandler) Logout(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.authService.Logout(ctx, req.RefreshToken); err != nil {
+		h.logger.Error("Failed to revoke refresh token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCurrentUser returns the authenticated user
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+	identity := services.IdentityFromContext(c.Request.Context())
+	c.JSON(http.StatusOK, h.convertToUserResponse(identity.User, true))
+}
+
+// GetUser returns the public profile of a user
+func (h *AuthHandler) GetUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := h.authService.GetUser(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.convertToUserResponse(user, false))
+}
+
+// UpdateUserRole changes the role of a user
+func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, err := h.authService.SetRole(ctx, id, entities.UserRole(req.Role))
+	if err != nil {
+		h.logger.Error("Failed to update user role", zap.String("user_id", id.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("User role updated", zap.String("user_id", user.ID.Hex()), zap.String("role", string(user.Role)))
+
+	c.JSON(http.StatusOK, h.convertToUserResponse(user, true))
+}
+
+// convertToUserResponse converts a user entity, including private fields only for its owner
+func (h *AuthHandler) convertToUserResponse(user *entities.User, private bool) UserResponse {
+	response := UserResponse{
+		ID:        user.ID.Hex(),
+		Username:  user.Username,
+		Role:      string(user.EffectiveRole()),
+		CreatedAt: user.CreatedAt,
+	}
+	if private {
+		response.Email = user.Email
+		response.Plan = user.Plan
+		response.HistoryPaused = &user.HistoryPaused
+	}
+	return response
+}
+
+func (h *AuthHandler) convertToTokenResponse(tokens *services.AuthTokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		User:                  h.convertToUserResponse(tokens.User, true),
+	}
+}
@@ -0,0 +1,430 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+	"youtube-backend/internal/infrastructure/storage"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const maxBatchItems = 50
+
+type BatchHandler struct {
+	videoService    *services.VideoService
+	batchService    *services.BatchService
+	playlistService *services.PlaylistService
+	minioClient     *storage.MinIOClient
+	logger          *zap.Logger
+}
+
+// BatchManifest describes a batch upload: shared defaults plus per-file overrides
+type BatchManifest struct {
+	Defaults BatchMetadata      `json:"defaults"`
+	Items    []BatchItemRequest `json:"items"`
+}
+
+type BatchMetadata struct {
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+	Category    string     `json:"category"`
+	Visibility  string     `json:"visibility"`
+	PublishAt   *time.Time `json:"publish_at"`
+	Encrypt     bool       `json:"encrypt"`
+	ChannelID   string     `json:"channel_id"`
+	PlaylistID  string     `json:"playlist_id"` // created videos are appended to it in item order
+}
+
+type BatchItemRequest struct {
+	File        string     `json:"file"`   // filename of a file sent in the "videos" form field
+	Object      string     `json:"object"` // object staged under uploads/<user ID>/ in the videos bucket
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+	Category    string     `json:"category"`
+	Visibility  string     `json:"visibility"`
+	PublishAt   *time.Time `json:"publish_at"`
+	Encrypt     *bool      `json:"encrypt"`
+	ChannelID   string     `json:"channel_id"`
+	PlaylistID  string     `json:"playlist_id"`
+}
+
+type BatchItemResult struct {
+	Index   int    `json:"index"`
+	Source  string `json:"source"`
+	VideoID string `json:"video_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BatchUploadResponse struct {
+	BatchID string            `json:"batch_id,omitempty"`
+	Items   []BatchItemResult `json:"items"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+}
+
+type BatchProgressResponse struct {
+	BatchID          string                       `json:"batch_id"`
+	Status           string                       `json:"status"`
+	Progress         int                          `json:"progress"`
+	TotalVideos      int                          `json:"total_videos"`
+	ReadyVideos      int                          `json:"ready_videos"`
+	FailedVideos     int                          `json:"failed_videos"`
+	ProcessingVideos int                          `json:"processing_videos"`
+	TotalJobs        int                          `json:"total_jobs"`
+	CompletedJobs    int                          `json:"completed_jobs"`
+	FailedJobs       int                          `json:"failed_jobs"`
+	Videos           []BatchVideoProgressResponse `json:"videos"`
+	CreatedAt        time.Time                    `json:"created_at"`
+}
+
+type BatchVideoProgressResponse struct {
+	VideoID  string `json:"video_id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Progress int    `json:"progress"`
+}
+
+func NewBatchHandler(videoService *services.VideoService, batchService *services.BatchService, playlistService *services.PlaylistService, minioClient *storage.MinIOClient, logger *zap.Logger) *BatchHandler {
+	return &BatchHandler{
+		videoService:    videoService,
+		batchService:    batchService,
+		playlistService: playlistService,
+		minioClient:     minioClient,
+		logger:          logger,
+	}
+}
+
+// CreateBatch uploads several videos at once, either as multipart files or
+// as a JSON manifest referencing objects that are already in storage
+func (h *BatchHandler) CreateBatch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+	defer cancel()
+
+	var manifest BatchManifest
+	files := make(map[string]*multipart.FileHeader)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+			h.logger.Error("Failed to parse multipart form", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form data"})
+			return
+		}
+
+		if raw := c.PostForm("manifest"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &manifest); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid manifest: " + err.Error()})
+				return
+			}
+		}
+
+		// Items reference files by name, so names must be unique
+		uploads := c.Request.MultipartForm.File["videos"]
+		for _, header := range uploads {
+			if files[header.Filename] != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File %q was uploaded more than once", header.Filename)})
+				return
+			}
+			files[header.Filename] = header
+		}
+
+		// Without explicit items every uploaded file becomes one video, in upload order
+		if len(manifest.Items) == 0 {
+			for _, header := range uploads {
+				manifest.Items = append(manifest.Items, BatchItemRequest{File: header.Filename})
+			}
+		}
+	} else if err := c.ShouldBindJSON(&manifest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid manifest: " + err.Error()})
+		return
+	}
+
+	if len(manifest.Items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch contains no videos"})
+		return
+	}
+	if len(manifest.Items) > maxBatchItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Batch cannot contain more than %d videos", maxBatchItems)})
+		return
+	}
+
+	// Reject the whole batch up front when it names objects or playlists the caller may not use
+	playlistIDs := make([]primitive.ObjectID, len(manifest.Items))
+	objects := make(map[string]bool)
+	for i, item := range manifest.Items {
+		if item.Object != "" {
+			if err := services.CheckStagedObject(c.Request.Context(), item.Object); err != nil {
+				c.JSON(errorStatus(err, http.StatusForbidden), gin.H{"error": err.Error()})
+				return
+			}
+			// Staged objects are moved into place, so each can only back one video
+			if objects[item.Object] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Object %q is used by more than one item", item.Object)})
+				return
+			}
+			objects[item.Object] = true
+		}
+
+		playlistID := manifest.Defaults.PlaylistID
+		if item.PlaylistID != "" {
+			playlistID = item.PlaylistID
+		}
+		if playlistID == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(playlistID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+			return
+		}
+		if err := h.playlistService.CheckPlaylistChange(ctx, id); err != nil {
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+			return
+		}
+		playlistIDs[i] = id
+	}
+
+	uploadedBy := services.IdentityFromContext(c.Request.Context()).UserID()
+
+	response := BatchUploadResponse{Items: make([]BatchItemResult, len(manifest.Items))}
+	var videoIDs []primitive.ObjectID
+
+	for i, item := range manifest.Items {
+		result := BatchItemResult{Index: i, Source: item.File}
+		if item.Object != "" {
+			result.Source = item.Object
+		}
+
+		// A video that was created but could not be processed is still recorded, as failed
+		video, err := h.createBatchItem(ctx, manifest.Defaults, uploadedBy, item, files)
+		if video != nil {
+			result.VideoID = video.ID.Hex()
+			videoIDs = append(videoIDs, video.ID)
+		}
+		if err == nil && !playlistIDs[i].IsZero() {
+			if _, addErr := h.playlistService.AddVideo(ctx, playlistIDs[i], video.ID, nil); addErr != nil {
+				err = fmt.Errorf("video was created but not added to the playlist: %w", addErr)
+			}
+		}
+		if err != nil {
+			h.logger.Error("Failed to create batch item",
+				zap.Int("index", i),
+				zap.String("source", result.Source),
+				zap.Error(err))
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			response.Created++
+		}
+
+		response.Items[i] = result
+	}
+
+	if len(videoIDs) == 0 {
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	batch, err := h.batchService.CreateBatch(ctx, uploadedBy, videoIDs)
+	if err != nil {
+		h.logger.Error("Failed to create batch", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create batch"})
+		return
+	}
+	response.BatchID = batch.ID.Hex()
+
+	h.logger.Info("Batch uploaded",
+		zap.String("batch_id", response.BatchID),
+		zap.Int("created", response.Created),
+		zap.Int("failed", response.Failed))
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetBatch returns the aggregate processing progress of a batch
+func (h *BatchHandler) GetBatch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	batchID := c.Param("id")
+	objectID, err := primitive.ObjectIDFromHex(batchID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid batch ID"})
+		return
+	}
+
+	progress, err := h.batchService.GetBatchProgress(ctx, objectID)
+	if err != nil {
+		h.logger.Error("Failed to get batch progress", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Batch not found"})
+		return
+	}
+
+	status := "processing"
+	if progress.IsFinished() {
+		status = "completed"
+		if progress.FailedVideos > 0 {
+			status = "completed_with_errors"
+		}
+	}
+
+	videos := make([]BatchVideoProgressResponse, len(progress.Videos))
+	for i, video := range progress.Videos {
+		videos[i] = BatchVideoProgressResponse{
+			VideoID:  video.ID.Hex(),
+			Title:    video.Title,
+			Status:   string(video.Status),
+			Progress: progress.VideoProgress[video.ID],
+		}
+	}
+
+	c.JSON(http.StatusOK, BatchProgressResponse{
+		BatchID:          progress.Batch.ID.Hex(),
+		Status:           status,
+		Progress:         progress.Progress,
+		TotalVideos:      len(progress.Batch.VideoIDs),
+		ReadyVideos:      progress.ReadyVideos,
+		FailedVideos:     progress.FailedVideos,
+		ProcessingVideos: progress.ProcessingVideos,
+		TotalJobs:        progress.TotalJobs,
+		CompletedJobs:    progress.CompletedJobs,
+		FailedJobs:       progress.FailedJobs,
+		Videos:           videos,
+		CreatedAt:        progress.Batch.CreatedAt,
+	})
+}
+
+// createBatchItem creates, stores and schedules a single video of a batch. When storing or scheduling
+// fails after the video was created, the video is marked as failed and returned with the error.
+func (h *BatchHandler) createBatchItem(ctx context.Context, defaults BatchMetadata, uploadedBy string, item BatchItemRequest, files map[string]*multipart.FileHeader) (*entities.Video, error) {
+	var header *multipart.FileHeader
+	var filename string
+	var size int64
+
+	switch {
+	case item.File != "" && item.Object != "":
+		return nil, fmt.Errorf("item must reference either a file or an object, not both")
+	case item.File != "":
+		header = files[item.File]
+		if header == nil {
+			return nil, fmt.Errorf("file %q was not uploaded", item.File)
+		}
+		filename, size = header.Filename, header.Size
+	case item.Object != "":
+		info, err := h.minioClient.GetFileInfo(ctx, item.Object)
+		if err != nil {
+			return nil, fmt.Errorf("object %q not found", item.Object)
+		}
+		filename, size = path.Base(item.Object), info.Size
+	default:
+		return nil, fmt.Errorf("item must reference a file or an object")
+	}
+
+	// Per-item values override the shared defaults
+	input := services.CreateVideoInput{
+		Title:            item.Title,
+		Description:      defaults.Description,
+		UploadedBy:       uploadedBy,
+		OriginalFilename: filename,
+		Size:             size,
+		Tags:             defaults.Tags,
+		Category:         defaults.Category,
+		Visibility:       entities.Visibility(defaults.Visibility),
+		PublishAt:        defaults.PublishAt,
+		Encrypted:        defaults.Encrypt,
+	}
+	if input.Title == "" {
+		input.Title = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	if item.Description != "" {
+		input.Description = item.Description
+	}
+	if item.Tags != nil {
+		input.Tags = item.Tags
+	}
+	if item.Category != "" {
+		input.Category = item.Category
+	}
+	if item.Visibility != "" {
+		input.Visibility = entities.Visibility(item.Visibility)
+	}
+	if item.PublishAt != nil {
+		input.PublishAt = item.PublishAt
+	}
+	if item.Encrypt != nil {
+		input.Encrypted = *item.Encrypt
+	}
+	channelID := defaults.ChannelID
+	if item.ChannelID != "" {
+		channelID = item.ChannelID
+	}
+	parsedChannelID, err := parseChannelID(channelID)
+	if err != nil {
+		return nil, err
+	}
+	input.ChannelID = parsedChannelID
+
+	video, err := h.videoService.CreateVideo(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.storeBatchItem(ctx, video, header, item.Object); err != nil {
+		h.markFailed(ctx, video)
+		return video, err
+	}
+
+	if err := h.videoService.ScheduleProcessingJobs(ctx, video.ID); err != nil {
+		h.markFailed(ctx, video)
+		return video, fmt.Errorf("failed to schedule processing jobs: %w", err)
+	}
+
+	return video, nil
+}
+
+// markFailed marks a batch video that cannot be processed as failed
+func (h *BatchHandler) markFailed(ctx context.Context, video *entities.Video) {
+	if err := h.videoService.UpdateVideoStatus(ctx, video.ID, entities.VideoStatusFailed); err != nil {
+		h.logger.Error("Failed to mark video as failed", zap.String("video_id", video.ID.Hex()), zap.Error(err))
+		return
+	}
+	video.Status = entities.VideoStatusFailed
+}
+
+// storeBatchItem puts the source file of a batch item at the video's original object name. A staged
+// object is moved rather than copied, so it is not left behind outside the uploader's storage usage.
+func (h *BatchHandler) storeBatchItem(ctx context.Context, video *entities.Video, header *multipart.FileHeader, object string) error {
+	if object != "" {
+		if err := h.minioClient.CopyFile(ctx, object, video.OriginalObjectName()); err != nil {
+			return fmt.Errorf("failed to copy object: %w", err)
+		}
+		if err := h.minioClient.DeleteFile(ctx, object); err != nil {
+			h.logger.Warn("Failed to delete staged object", zap.String("object", object), zap.Error(err))
+		}
+		return nil
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	ext := filepath.Ext(header.Filename)
+	if err := h.minioClient.UploadFile(ctx, video.OriginalObjectName(), file, header.Size, "video/"+strings.TrimPrefix(ext, ".")); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+	"youtube-backend/internal/infrastructure/storage"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type CaptionHandler struct {
+	captionService  *services.CaptionService
+	playbackService *services.PlaybackService
+	minioClient     *storage.MinIOClient
+	logger          *zap.Logger
+}
+
+type CaptionResponse struct {
+	Language   string    `json:"language"`
+	Label      string    `json:"label"`
+	Cues       int       `json:"cues"`
+	URL        string    `json:"url"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+func NewCaptionHandler(captionService *services.CaptionService, playbackService *services.PlaybackService, minioClient *storage.MinIOClient, logger *zap.Logger) *CaptionHandler {
+	return &CaptionHandler{
+		captionService:  captionService,
+		playbackService: playbackService,
+		minioClient:     minioClient,
+		logger:          logger,
+	}
+}
+
+// UploadCaption stores the SRT or WebVTT file in the "file" form field as the video's caption track
+// in the language of the path, replacing any track in that language
+func (h *CaptionHandler) UploadCaption(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No caption file provided"})
+		return
+	}
+	defer file.Close()
+
+	// Reading one byte past the limit lets the service reject oversized files
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxCaptionFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read caption file"})
+		return
+	}
+
+	caption, err := h.captionService.UploadCaption(ctx, videoID, services.UploadCaptionInput{
+		Language: c.Param("lang"),
+		Label:    c.PostForm("label"),
+		Data:     data,
+	})
+	if err != nil {
+		h.logger.Error("Failed to upload caption", zap.String("video_id", videoID.Hex()), zap.String("language", c.Param("lang")), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToCaptionResponse(videoID, *caption))
+}
+
+// GetCaption serves a caption track as WebVTT. A playback token grants access to hidden videos, as
+// for HLS; without one the caller must be allowed to view the video.
+func (h *CaptionHandler) GetCaption(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var video *entities.Video
+	var caption *entities.Caption
+	if token := c.Query("token"); token != "" {
+		video, err = h.playbackService.GetVideoWithToken(ctx, videoID, token, c.ClientIP(), playbackSession(c))
+		if err == nil {
+			if caption = video.GetCaption(entities.NormalizeLanguage(c.Param("lang"))); caption == nil {
+				err = services.ErrCaptionNotFound
+			}
+		}
+	} else {
+		video, caption, err = h.captionService.GetCaption(ctx, videoID, c.Param("lang"))
+	}
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+		return
+	}
+
+	object, err := h.minioClient.DownloadFile(ctx, caption.Object)
+	if err != nil {
+		h.logger.Error("Failed to download caption", zap.String("object", caption.Object), zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": "Caption not found in storage"})
+		return
+	}
+	defer object.Close()
+
+	c.Header("Content-Type", "text/vtt; charset=utf-8")
+	c.Header("Cache-Control", cacheControl(video))
+	c.Header("Content-Language", caption.Language)
+
+	if _, err := io.Copy(c.Writer, object); err != nil {
+		h.logger.Error("Failed to stream caption", zap.String("object", caption.Object), zap.Error(err))
+	}
+}
+
+// DeleteCaption removes a caption track of a video
+func (h *CaptionHandler) DeleteCaption(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	if err := h.captionService.DeleteCaption(ctx, videoID, c.Param("lang")); err != nil {
+		h.logger.Error("Failed to delete caption", zap.String("video_id", videoID.Hex()), zap.String("language", c.Param("lang")), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// captionURL is where a caption track is served
+func captionURL(videoID primitive.ObjectID, language string) string {
+	return "/api/v1/videos/" + videoID.Hex() + "/captions/" + language
+}
+
+func convertToCaptionResponse(videoID primitive.ObjectID, caption entities.Caption) CaptionResponse {
+	return CaptionResponse{
+		Language:   caption.Language,
+		Label:      caption.Label,
+		Cues:       caption.Cues,
+		URL:        captionURL(videoID, caption.Language),
+		UploadedAt: caption.UploadedAt,
+	}
+}
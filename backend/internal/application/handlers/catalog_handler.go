@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTagLimit = 50
+	maxTagLimit     = 200
+)
+
+type CatalogHandler struct {
+	catalogService *services.CatalogService
+	logger         *zap.Logger
+}
+
+type CategoryResponse struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	VideoCount int64  `json:"video_count"`
+}
+
+type TagResponse struct {
+	Tag        string `json:"tag"`
+	VideoCount int64  `json:"video_count"`
+}
+
+func NewCatalogHandler(catalogService *services.CatalogService, logger *zap.Logger) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+		logger:         logger,
+	}
+}
+
+// GetCategories returns the category taxonomy with the number of videos in each category
+func (h *CatalogHandler) GetCategories(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	categories, err := h.catalogService.ListCategories(ctx)
+	if err != nil {
+		h.logger.Error("Failed to get categories", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get categories"})
+		return
+	}
+
+	categoryResponses := make([]CategoryResponse, len(categories))
+	for i, category := range categories {
+		categoryResponses[i] = CategoryResponse{
+			Slug:       category.Slug,
+			Name:       category.Name,
+			VideoCount: category.VideoCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categoryResponses})
+}
+
+// GetTags returns the most used tags with their video counts, optionally those starting with prefix
+func (h *CatalogHandler) GetTags(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultTagLimit)))
+	if err != nil || limit < 1 || limit > maxTagLimit {
+		limit = defaultTagLimit
+	}
+
+	tags, err := h.catalogService.ListTags(ctx, c.Query("prefix"), limit)
+	if err != nil {
+		h.logger.Error("Failed to get tags", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tags"})
+		return
+	}
+
+	tagResponses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponses[i] = TagResponse{Tag: tag.Tag, VideoCount: tag.Count}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tagResponses})
+}
@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+	"youtube-backend/internal/infrastructure/storage"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ChannelHandler struct {
+	channelService *services.ChannelService
+	minioClient    *storage.MinIOClient
+	logger         *zap.Logger
+}
+
+type CreateChannelRequest struct {
+	Handle      string `json:"handle" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// UpdateChannelRequest changes a channel's profile; omitted fields are left unchanged
+type UpdateChannelRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type ChannelResponse struct {
+	ID              string    `json:"id"`
+	Owner           string    `json:"owner"`
+	Handle          string    `json:"handle"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	AvatarURL       string    `json:"avatar_url,omitempty"`
+	BannerURL       string    `json:"banner_url,omitempty"`
+	SubscriberCount int64     `json:"subscriber_count"`
+	Subscribed      *bool     `json:"subscribed,omitempty"` // whether the caller is subscribed; only on single channel responses
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ChannelListResponse struct {
+	Channels []ChannelResponse `json:"channels"`
+}
+
+type SubscriptionResponse struct {
+	Channel      ChannelResponse `json:"channel"`
+	SubscribedAt time.Time       `json:"subscribed_at"`
+}
+
+type SubscriptionListResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+	Total         int64                  `json:"total"`
+	Limit         int                    `json:"limit"`
+	NextCursor    string                 `json:"next_cursor,omitempty"`
+}
+
+func NewChannelHandler(channelService *services.ChannelService, minioClient *storage.MinIOClient, logger *zap.Logger) *ChannelHandler {
+	return &ChannelHandler{
+		channelService: channelService,
+		minioClient:    minioClient,
+		logger:         logger,
+	}
+}
+
+// CreateChannel creates a channel owned by the caller
+func (h *ChannelHandler) CreateChannel(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req CreateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	channel, err := h.channelService.CreateChannel(ctx, services.CreateChannelInput{
+		Handle:      req.Handle,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.logger.Error("Failed to create channel", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, convertToChannelResponse(channel))
+}
+
+// GetChannels lists the channels of one owner (a user ID or "me")
+func (h *ChannelHandler) GetChannels(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	owner := c.Query("owner")
+	if owner == "me" {
+		identity := services.IdentityFromContext(c.Request.Context())
+		if identity == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "owner=me requires authentication"})
+			return
+		}
+		owner = identity.UserID()
+	}
+
+	channels, err := h.channelService.ListChannels(ctx, owner)
+	if err != nil {
+		h.logger.Error("Failed to get channels", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]ChannelResponse, len(channels))
+	for i, channel := range channels {
+		responses[i] = convertToChannelResponse(channel)
+	}
+
+	c.JSON(http.StatusOK, ChannelListResponse{Channels: responses})
+}
+
+// GetChannel returns a channel by ID or @handle, with whether the caller is subscribed
+func (h *ChannelHandler) GetChannel(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.GetChannel(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	subscribed, err := h.channelService.Subscribed(ctx, channel.ID)
+	if err != nil {
+		h.logger.Error("Failed to check subscription", zap.String("channel_id", channel.ID.Hex()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get channel"})
+		return
+	}
+
+	response := convertToChannelResponse(channel)
+	response.Subscribed = &subscribed
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateChannel changes the name or description of a channel
+func (h *ChannelHandler) UpdateChannel(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req UpdateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	channel, err := h.channelService.UpdateChannel(ctx, c.Param("id"), services.UpdateChannelInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.logger.Error("Failed to update channel", zap.String("channel", c.Param("id")), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToChannelResponse(channel))
+}
+
+// UploadAvatar replaces a channel's avatar with the image in the "image" form field
+func (h *ChannelHandler) UploadAvatar(c *gin.Context) {
+	h.uploadImage(c, entities.ChannelAvatar)
+}
+
+// UploadBanner replaces a channel's banner with the image in the "image" form field
+func (h *ChannelHandler) UploadBanner(c *gin.Context) {
+	h.uploadImage(c, entities.ChannelBanner)
+}
+
+// GetAvatar serves a channel's avatar
+func (h *ChannelHandler) GetAvatar(c *gin.Context) {
+	h.serveImage(c, entities.ChannelAvatar)
+}
+
+// GetBanner serves a channel's banner
+func (h *ChannelHandler) GetBanner(c *gin.Context) {
+	h.serveImage(c, entities.ChannelBanner)
+}
+
+// Subscribe subscribes the caller to a channel
+func (h *ChannelHandler) Subscribe(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.Subscribe(ctx, c.Param("id"))
+	if err != nil {
+		h.logger.Error("Failed to subscribe", zap.String("channel", c.Param("id")), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	subscribed := true
+	response := convertToChannelResponse(channel)
+	response.Subscribed = &subscribed
+	c.JSON(http.StatusOK, response)
+}
+
+// Unsubscribe removes the caller's subscription to a channel
+func (h *ChannelHandler) Unsubscribe(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.Unsubscribe(ctx, c.Param("id"))
+	if err != nil {
+		h.logger.Error("Failed to unsubscribe", zap.String("channel", c.Param("id")), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	subscribed := false
+	response := convertToChannelResponse(channel)
+	response.Subscribed = &subscribed
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSubscriptions lists the channels the caller subscribed to, most recent first
+func (h *ChannelHandler) GetSubscriptions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	page, err := h.channelService.ListSubscriptions(ctx, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get subscriptions", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	subscriptions := make([]SubscriptionResponse, 0, len(page.Subscriptions))
+	for _, subscription := range page.Subscriptions {
+		channel, ok := page.Channels[subscription.ChannelID]
+		if !ok {
+			continue
+		}
+		subscriptions = append(subscriptions, SubscriptionResponse{
+			Channel:      convertToChannelResponse(channel),
+			SubscribedAt: subscription.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, SubscriptionListResponse{
+		Subscriptions: subscriptions,
+		Total:         page.Total,
+		Limit:         limit,
+		NextCursor:    page.NextCursor,
+	})
+}
+
+// GetFeed returns the latest videos from the channels the caller subscribed to
+func (h *ChannelHandler) GetFeed(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	page, err := h.channelService.Feed(ctx, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get subscription feed", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoListResponse(page, limit))
+}
+
+// uploadImage stores the image of a multipart upload as a channel's avatar or banner
+func (h *ChannelHandler) uploadImage(c *gin.Context, image entities.ChannelImage) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	file, header, err := c.Request.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No image file provided"})
+		return
+	}
+	defer file.Close()
+
+	// The content type is sniffed from the data rather than trusted from the client
+	reader := bufio.NewReader(file)
+	head, err := reader.Peek(512)
+	if err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image"})
+		return
+	}
+	contentType := http.DetectContentType(head)
+
+	channel, err := h.channelService.SetImage(ctx, c.Param("id"), image, reader, header.Size, contentType)
+	if err != nil {
+		h.logger.Error("Failed to set channel image", zap.String("channel", c.Param("id")), zap.String("image", string(image)), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToChannelResponse(channel))
+}
+
+// serveImage streams a channel's avatar or banner from MinIO storage
+func (h *ChannelHandler) serveImage(c *gin.Context, image entities.ChannelImage) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.GetChannel(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	objectName := channel.ImageObject(image)
+	if objectName == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Channel has no " + string(image)})
+		return
+	}
+
+	object, err := h.minioClient.DownloadThumbnail(ctx, objectName)
+	if err != nil {
+		h.logger.Error("Failed to download channel image", zap.String("object", objectName), zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found in storage"})
+		return
+	}
+	defer object.Close()
+
+	info, err := object.Stat()
+	if err != nil {
+		h.logger.Error("Failed to stat channel image", zap.String("object", objectName), zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found in storage"})
+		return
+	}
+
+	c.Header("Content-Type", info.ContentType)
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("Content-Disposition", "inline")
+
+	if _, err := io.Copy(c.Writer, object); err != nil {
+		h.logger.Error("Failed to stream channel image", zap.String("object", objectName), zap.Error(err))
+	}
+}
+
+func convertToChannelResponse(channel *entities.Channel) ChannelResponse {
+	response := ChannelResponse{
+		ID:              channel.ID.Hex(),
+		Owner:           channel.Owner,
+		Handle:          channel.Handle,
+		Name:            channel.Name,
+		Description:     channel.Description,
+		SubscriberCount: channel.SubscriberCount,
+		CreatedAt:       channel.CreatedAt,
+		UpdatedAt:       channel.UpdatedAt,
+	}
+	// The version parameter changes with every update so cached images are refetched
+	version := "?v=" + strconv.FormatInt(channel.UpdatedAt.Unix(), 10)
+	if channel.AvatarObject != "" {
+		response.AvatarURL = "/api/v1/channels/" + channel.ID.Hex() + "/avatar" + version
+	}
+	if channel.BannerObject != "" {
+		response.BannerURL = "/api/v1/channels/" + channel.ID.Hex() + "/banner" + version
+	}
+	return response
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type ChapterHandler struct {
+	chapterService  *services.ChapterService
+	playbackService *services.PlaybackService
+	logger          *zap.Logger
+}
+
+type ChapterResponse struct {
+	Start float64 `json:"start"` // in seconds
+	End   float64 `json:"end"`   // in seconds; zero for the last chapter while the duration is not known
+	Title string  `json:"title"`
+}
+
+type ChaptersResponse struct {
+	Chapters []ChapterResponse `json:"chapters"`
+	Source   string            `json:"source,omitempty"` // "manual" or "description"
+}
+
+// SetChaptersRequest replaces the chapters of a video; an empty list falls back to the description
+type SetChaptersRequest struct {
+	Chapters []struct {
+		Start float64 `json:"start"`
+		Title string  `json:"title"`
+	} `json:"chapters"`
+}
+
+func NewChapterHandler(chapterService *services.ChapterService, playbackService *services.PlaybackService, logger *zap.Logger) *ChapterHandler {
+	return &ChapterHandler{
+		chapterService:  chapterService,
+		playbackService: playbackService,
+		logger:          logger,
+	}
+}
+
+// GetChapters returns the chapters of a video and where they come from
+func (h *ChapterHandler) GetChapters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	video, err := h.chapterService.GetVideo(ctx, videoID)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToChaptersResponse(video))
+}
+
+// SetChapters replaces the chapters of a video
+func (h *ChapterHandler) SetChapters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req SetChaptersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	chapters := make([]entities.Chapter, len(req.Chapters))
+	for i, chapter := range req.Chapters {
+		chapters[i] = entities.Chapter{Start: chapter.Start, Title: chapter.Title}
+	}
+
+	video, err := h.chapterService.SetChapters(ctx, videoID, chapters)
+	if err != nil {
+		h.logger.Error("Failed to set chapters", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToChaptersResponse(video))
+}
+
+// GetChapterTrack serves the chapters of a video as a WebVTT chapters track. A playback token grants
+// access to hidden videos, as for captions.
+func (h *ChapterHandler) GetChapterTrack(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var video *entities.Video
+	if token := c.Query("token"); token != "" {
+		video, err = h.playbackService.GetVideoWithToken(ctx, videoID, token, c.ClientIP(), playbackSession(c))
+	} else {
+		video, err = h.chapterService.GetVideo(ctx, videoID)
+	}
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+		return
+	}
+
+	track, err := services.ChapterTrack(video)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", cacheControl(video))
+	c.Data(http.StatusOK, "text/vtt; charset=utf-8", track)
+}
+
+func convertToChapterResponses(video *entities.Video) ([]ChapterResponse, entities.ChapterSource) {
+	chapters, source := video.ResolveChapters()
+	responses := make([]ChapterResponse, len(chapters))
+	for i, chapter := range chapters {
+		responses[i] = ChapterResponse{
+			Start: chapter.Start,
+			End:   entities.ChapterEnd(chapters, i, video.Duration),
+			Title: chapter.Title,
+		}
+	}
+	return responses, source
+}
+
+func convertToChaptersResponse(video *entities.Video) ChaptersResponse {
+	chapters, source := convertToChapterResponses(video)
+	return ChaptersResponse{Chapters: chapters, Source: string(source)}
+}
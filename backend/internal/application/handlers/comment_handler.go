@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type CommentHandler struct {
+	commentService *services.CommentService
+	logger         *zap.Logger
+}
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID string `json:"parent_id"` // comment to reply to; empty for a new thread
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type CommentResponse struct {
+	ID         string     `json:"id"`
+	VideoID    string     `json:"video_id"`
+	ParentID   string     `json:"parent_id,omitempty"`
+	AuthorID   string     `json:"author_id"`
+	AuthorName string     `json:"author_name"`
+	Body       string     `json:"body"`
+	Status     string     `json:"status"`
+	ReplyCount int64      `json:"reply_count"`
+	EditedAt   *time.Time `json:"edited_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CommentListResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	Total      int64             `json:"total"`
+	Limit      int               `json:"limit"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+func NewCommentHandler(commentService *services.CommentService, logger *zap.Logger) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		logger:         logger,
+	}
+}
+
+// CreateComment adds a comment or a reply to a video
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	var parentID *primitive.ObjectID
+	if req.ParentID != "" {
+		id, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent comment ID"})
+			return
+		}
+		parentID = &id
+	}
+
+	comment, err := h.commentService.CreateComment(ctx, videoID, parentID, req.Body)
+	if err != nil {
+		h.logger.Warn("Failed to create comment", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, convertToCommentResponse(comment))
+}
+
+// GetComments returns a page of the comment threads of a video, sorted by newest (default) or top
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	limit, cursor := pageParams(c)
+	sort := repositories.CommentSort(c.DefaultQuery("sort", string(repositories.CommentSortNewest)))
+
+	page, err := h.commentService.ListComments(ctx, videoID, sort, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get comments", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToCommentListResponse(page, limit))
+}
+
+// GetReplies returns a page of the replies to a comment, oldest first
+func (h *CommentHandler) GetReplies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	commentID, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	limit, cursor := pageParams(c)
+
+	page, err := h.commentService.ListReplies(ctx, commentID, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get replies", zap.String("comment_id", commentID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToCommentListResponse(page, limit))
+}
+
+// UpdateComment lets the author edit a comment
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	commentID, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(ctx, commentID, req.Body)
+	if err != nil {
+		h.logger.Warn("Failed to update comment", zap.String("comment_id", commentID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToCommentResponse(comment))
+}
+
+// DeleteComment deletes the caller's own comment, or removes any comment when the caller is a moderator
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	commentID, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteComment(ctx, commentID); err != nil {
+		h.logger.Error("Failed to delete comment", zap.String("comment_id", commentID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseCommentID reads the comment ID path parameter, responding with 400 when it is malformed
+func parseCommentID(c *gin.Context) (primitive.ObjectID, bool) {
+	commentID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return primitive.NilObjectID, false
+	}
+	return commentID, true
+}
+
+func convertToCommentListResponse(page *services.CommentPage, limit int) CommentListResponse {
+	commentResponses := make([]CommentResponse, len(page.Comments))
+	for i, comment := range page.Comments {
+		commentResponses[i] = convertToCommentResponse(comment)
+	}
+
+	return CommentListResponse{
+		Comments:   commentResponses,
+		Total:      page.Total,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// convertToCommentResponse converts a comment to API response. Hidden comments keep their
+// place in a thread but show neither body nor author.
+func convertToCommentResponse(comment *entities.Comment) CommentResponse {
+	response := CommentResponse{
+		ID:         comment.ID.Hex(),
+		VideoID:    comment.VideoID.Hex(),
+		AuthorID:   comment.AuthorID,
+		AuthorName: comment.AuthorName,
+		Body:       comment.Body,
+		Status:     string(comment.Status),
+		ReplyCount: comment.ReplyCount,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+	if comment.ParentID != nil {
+		response.ParentID = comment.ParentID.Hex()
+	}
+	if !comment.IsVisible() {
+		response.AuthorID = ""
+		response.AuthorName = ""
+		response.Body = ""
+	}
+	return response
+}
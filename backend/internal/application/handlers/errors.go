@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"youtube-backend/internal/domain/services"
+)
+
+// errorStatus maps domain errors to HTTP status codes, falling back to the given status
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrFileTooLarge), errors.Is(err, services.ErrStorageQuotaExceeded):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrDailyUploadLimitReached):
+		return http.StatusTooManyRequests
+	case errors.Is(err, services.ErrVideoBusy), errors.Is(err, services.ErrUserExists), errors.Is(err, services.ErrPlaylistConflict),
+		errors.Is(err, services.ErrHandleTaken):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrCommentRejected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrVideoNotFound), errors.Is(err, services.ErrContentKeyNotFound), errors.Is(err, services.ErrPlaylistNotFound),
+		errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrChannelNotFound),
+		errors.Is(err, services.ErrCaptionNotFound), errors.Is(err, services.ErrChaptersNotFound),
+		errors.Is(err, services.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	default:
+		return fallback
+	}
+}
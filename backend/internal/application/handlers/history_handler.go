@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type HistoryHandler struct {
+	historyService *services.HistoryService
+	logger         *zap.Logger
+}
+
+type HistorySettingsRequest struct {
+	Paused *bool `json:"paused" binding:"required"`
+}
+
+type HistoryEntryResponse struct {
+	Video          VideoResponse `json:"video"`
+	Position       float64       `json:"position"`
+	ResumePosition float64       `json:"resume_position"`
+	Progress       float64       `json:"progress"` // watched share of the video, 0 to 1
+	Completed      bool          `json:"completed"`
+	WatchedAt      time.Time     `json:"watched_at"`
+}
+
+type HistoryResponse struct {
+	Entries    []HistoryEntryResponse `json:"entries"`
+	Paused     bool                   `json:"paused"`
+	Total      int64                  `json:"total"`
+	Limit      int                    `json:"limit"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+func NewHistoryHandler(historyService *services.HistoryService, logger *zap.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+		logger:         logger,
+	}
+}
+
+// GetHistory lists the caller's recently watched videos with their progress
+func (h *HistoryHandler) GetHistory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	page, err := h.historyService.ListHistory(ctx, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get watch history", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := make([]HistoryEntryResponse, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = HistoryEntryResponse{
+			Video:          convertToVideoResponse(entry.Video),
+			Position:       entry.Progress.Position,
+			ResumePosition: entry.Progress.ResumePosition(),
+			Progress:       entry.Progress.Fraction(),
+			Completed:      entry.Progress.Completed,
+			WatchedAt:      entry.Progress.WatchedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, HistoryResponse{
+		Entries:    entries,
+		Paused:     page.Paused,
+		Total:      page.Total,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// ClearHistory deletes the caller's whole watch history
+func (h *HistoryHandler) ClearHistory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	removed, err := h.historyService.ClearHistory(ctx)
+	if err != nil {
+		h.logger.Error("Failed to clear watch history", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+// RemoveFromHistory deletes one video from the caller's watch history
+func (h *HistoryHandler) RemoveFromHistory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("videoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	if err := h.historyService.RemoveFromHistory(ctx, videoID); err != nil {
+		h.logger.Error("Failed to remove video from watch history", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateSettings pauses or resumes recording the caller's watch history
+func (h *HistoryHandler) UpdateSettings(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req HistorySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.historyService.SetPaused(ctx, *req.Paused); err != nil {
+		h.logger.Error("Failed to update watch history settings", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"paused": *req.Paused})
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+	"youtube-backend/internal/infrastructure/storage"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	hlsPlaylistName = "index.m3u8"
+	// hlsSubtitlesGroup groups the caption tracks that every rendition offers
+	hlsSubtitlesGroup = "subs"
+)
+
+var (
+	hlsSegmentPattern = regexp.MustCompile(`^seg_\d+\.ts$`)
+	contentKeyURI     = regexp.MustCompile(`URI="key://([A-Za-z0-9_-]+)"`) // written by the worker for encrypted renditions
+)
+
+// defaultBandwidth is advertised for renditions whose bitrate cannot be estimated, matching the worker's maxrate
+var defaultBandwidth = map[string]int64{
+	"480p":  1_128_000,
+	"720p":  2_628_000,
+	"1080p": 2_628_000,
+}
+
+type HLSHandler struct {
+	playbackService *services.PlaybackService
+	minioClient     *storage.MinIOClient
+	logger          *zap.Logger
+}
+
+func NewHLSHandler(playbackService *services.PlaybackService, minioClient *storage.MinIOClient, logger *zap.Logger) *HLSHandler {
+	return &HLSHandler{
+		playbackService: playbackService,
+		minioClient:     minioClient,
+		logger:          logger,
+	}
+}
+
+// GetMasterPlaylist lists the HLS renditions of a video, each carrying the caller's playback token
+func (h *HLSHandler) GetMasterPlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	video, token, ok := h.authorize(ctx, c)
+	if !ok {
+		return
+	}
+
+	var playlist strings.Builder
+	playlist.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+
+	// Caption tracks are offered as subtitle renditions, each with its own media playlist
+	subtitles := ""
+	for _, caption := range video.Captions {
+		fmt.Fprintf(&playlist, "#EXT-X-MEDIA:TYPE=SUBTITLES,GROUP-ID=\"%s\",NAME=\"%s\",LANGUAGE=\"%s\",DEFAULT=NO,AUTOSELECT=YES,URI=\"%s/%s?token=%s\"\n",
+			hlsSubtitlesGroup, strings.ReplaceAll(caption.Label, `"`, `'`), caption.Language,
+			captionURL(video.ID, caption.Language), hlsPlaylistName, url.QueryEscape(token))
+		subtitles = ",SUBTITLES=\"" + hlsSubtitlesGroup + "\""
+	}
+
+	renditions := 0
+	for _, format := range video.Formats {
+		if format.Playlist == "" {
+			continue
+		}
+		fmt.Fprintf(&playlist, "#EXT-X-STREAM-INF:BANDWIDTH=%d,NAME=\"%s\"%s\n", estimateBandwidth(video, format), format.Quality, subtitles)
+		fmt.Fprintf(&playlist, "%s/%s?token=%s\n", url.PathEscape(format.Quality), hlsPlaylistName, url.QueryEscape(token))
+		renditions++
+	}
+
+	if renditions == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No HLS renditions available"})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "application/vnd.apple.mpegurl", []byte(playlist.String()))
+}
+
+// GetCaptionPlaylist serves the media playlist of a subtitle rendition: the whole WebVTT track as a single segment
+func (h *HLSHandler) GetCaptionPlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	video, token, ok := h.authorize(ctx, c)
+	if !ok {
+		return
+	}
+
+	caption := video.GetCaption(entities.NormalizeLanguage(c.Param("lang")))
+	if caption == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Caption not found"})
+		return
+	}
+
+	duration := math.Max(video.Duration, 1)
+
+	var playlist strings.Builder
+	playlist.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	fmt.Fprintf(&playlist, "#EXT-X-TARGETDURATION:%d\n", int64(math.Ceil(duration)))
+	playlist.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n")
+	fmt.Fprintf(&playlist, "#EXTINF:%.3f,\n", duration)
+	fmt.Fprintf(&playlist, "%s?token=%s\n", captionURL(video.ID, caption.Language), url.QueryEscape(token))
+	playlist.WriteString("#EXT-X-ENDLIST\n")
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "application/vnd.apple.mpegurl", []byte(playlist.String()))
+}
+
+// GetRenditionFile serves a rendition's media playlist or one of its segments.
+// Playlists are rewritten so segment and key requests carry the playback token.
+func (h *HLSHandler) GetRenditionFile(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	file := c.Param("file")
+	if file != hlsPlaylistName && !hlsSegmentPattern.MatchString(file) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	video, token, ok := h.authorize(ctx, c)
+	if !ok {
+		return
+	}
+
+	format := video.GetFormat(c.Param("quality"))
+	if format == nil || format.Playlist == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Quality not available"})
+		return
+	}
+
+	objectName := "videos/hls/" + path.Join(path.Dir(format.Playlist), file)
+	object, err := h.minioClient.DownloadFile(ctx, objectName)
+	if err != nil {
+		h.logger.Error("Failed to get HLS file", zap.String("object", objectName), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stream video"})
+		return
+	}
+	defer object.Close()
+
+	if file != hlsPlaylistName {
+		c.Header("Content-Type", "video/mp2t")
+		c.Header("Cache-Control", cacheControl(video))
+		if _, err := io.Copy(c.Writer, object); err != nil {
+			h.logger.Error("Failed to stream HLS segment", zap.Error(err))
+		}
+		return
+	}
+
+	raw, err := io.ReadAll(object)
+	if err != nil {
+		h.logger.Error("Failed to read HLS playlist", zap.String("object", objectName), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stream video"})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "application/vnd.apple.mpegurl", rewriteMediaPlaylist(raw, video.ID.Hex(), token))
+}
+
+// GetContentKey delivers the AES-128 key for segments of an encrypted video
+func (h *HLSHandler) GetContentKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	key, err := h.playbackService.GetContentKey(ctx, objectID, c.Param("keyId"), c.Query("token"), c.ClientIP(), playbackSession(c))
+	if err != nil {
+		h.logger.Warn("Content key request denied",
+			zap.String("video_id", objectID.Hex()),
+			zap.String("key_id", c.Param("keyId")),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/octet-stream", key)
+}
+
+// authorize verifies the request's playback token and returns the video it grants access to
+func (h *HLSHandler) authorize(ctx context.Context, c *gin.Context) (*entities.Video, string, bool) {
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return nil, "", false
+	}
+
+	token := c.Query("token")
+	video, err := h.playbackService.GetVideoWithToken(ctx, objectID, token, c.ClientIP(), playbackSession(c))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Invalid or expired playback token"})
+		return nil, "", false
+	}
+
+	return video, token, true
+}
+
+// rewriteMediaPlaylist appends the playback token to segment URIs and points
+// key URIs written by the worker at the key delivery endpoint
+func rewriteMediaPlaylist(playlist []byte, videoID, token string) []byte {
+	query := "?token=" + url.QueryEscape(token)
+	keyBase := "/api/v1/videos/" + videoID + "/keys/"
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXT-X-KEY:"):
+			line = contentKeyURI.ReplaceAllString(line, `URI="`+keyBase+`${1}`+query+`"`)
+		case !strings.HasPrefix(line, "#"):
+			line += query
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes()
+}
+
+// estimateBandwidth derives a rendition's peak bandwidth from its packaged size
+func estimateBandwidth(video *entities.Video, format entities.VideoFormat) int64 {
+	if format.HLSSize > 0 && video.Duration > 0 {
+		return int64(float64(format.HLSSize*8) / video.Duration)
+	}
+	if bandwidth, ok := defaultBandwidth[format.Quality]; ok {
+		return bandwidth
+	}
+	return defaultBandwidth["720p"]
+}
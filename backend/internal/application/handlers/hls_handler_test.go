@@ -0,0 +1,52 @@
+package handlers
+
+import "testing"
+
+func TestRewriteMediaPlaylist(t *testing.T) {
+	tests := []struct {
+		name     string
+		playlist string
+		token    string
+		want     string
+	}{
+		{
+			name:     "segments get the token",
+			playlist: "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:4.2,\nsegment_001.ts\n#EXT-X-ENDLIST\n",
+			token:    "abc",
+			want:     "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXTINF:6.0,\nsegment_000.ts?token=abc\n#EXTINF:4.2,\nsegment_001.ts?token=abc\n#EXT-X-ENDLIST\n",
+		},
+		{
+			name:     "key URIs point at the key endpoint",
+			playlist: "#EXTM3U\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k1\"\n#EXTINF:6.0,\nsegment_000.ts\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k_2-b\"\n#EXTINF:6.0,\nsegment_001.ts\n",
+			token:    "abc",
+			want:     "#EXTM3U\n#EXT-X-KEY:METHOD=AES-128,URI=\"/api/v1/videos/v1/keys/k1?token=abc\"\n#EXTINF:6.0,\nsegment_000.ts?token=abc\n#EXT-X-KEY:METHOD=AES-128,URI=\"/api/v1/videos/v1/keys/k_2-b?token=abc\"\n#EXTINF:6.0,\nsegment_001.ts?token=abc\n",
+		},
+		{
+			name:     "tokens are query escaped",
+			playlist: "#EXTINF:6.0,\nsegment_000.ts\n",
+			token:    "a+b/c=",
+			want:     "#EXTINF:6.0,\nsegment_000.ts?token=a%2Bb%2Fc%3D\n",
+		},
+		{
+			name:     "surrounding whitespace is trimmed",
+			playlist: "#EXTM3U\r\n\r\n  #EXTINF:6.0,\r\n  segment_000.ts  \r\n",
+			token:    "abc",
+			want:     "#EXTM3U\n\n#EXTINF:6.0,\nsegment_000.ts?token=abc\n",
+		},
+		{
+			name:     "other key URIs are left alone",
+			playlist: "#EXT-X-KEY:METHOD=AES-128,URI=\"https://example.com/key\"\n",
+			token:    "abc",
+			want:     "#EXT-X-KEY:METHOD=AES-128,URI=\"https://example.com/key\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(rewriteMediaPlaylist([]byte(tt.playlist), "v1", tt.token))
+			if got != tt.want {
+				t.Errorf("rewriteMediaPlaylist() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
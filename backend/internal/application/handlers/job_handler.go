@@ -32,6 +32,14 @@ type JobResponse struct {
 	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
 }
 
+type JobListResponse struct {
+	Jobs       []JobResponse `json:"jobs"`
+	Count      int           `json:"count"`
+	Total      int64         `json:"total"`
+	Limit      int           `json:"limit"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
 func NewJobHandler(processingService *services.ProcessingService, logger *zap.Logger) *JobHandler {
 	return &JobHandler{
 		processingService: processingService,
@@ -59,7 +67,7 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	job, err := h.processingService.GetJob(ctx, objectID)
 	if err != nil {
 		h.logger.Error("Failed to get job", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Job not found"})
 		return
 	}
 
@@ -81,7 +89,7 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, jobResponse)
 }
 
-// GetJobsByVideoID returns all jobs for a specific video
+// GetJobsByVideoID returns a page of the jobs of a specific video, newest first
 func (h *JobHandler) GetJobsByVideoID(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
@@ -98,51 +106,38 @@ func (h *JobHandler) GetJobsByVideoID(c *gin.Context) {
 		return
 	}
 
-	jobs, err := h.processingService.GetJobsByVideoID(ctx, objectID)
+	limit, cursor := pageParams(c)
+
+	page, err := h.processingService.GetJobsByVideoID(ctx, objectID, limit, cursor)
 	if err != nil {
 		h.logger.Error("Failed to get jobs for video", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get jobs"})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get jobs"})
 		return
 	}
 
-	jobResponses := make([]JobResponse, len(jobs))
-	for i, job := range jobs {
-		jobResponses[i] = JobResponse{
-			ID:           job.ID.Hex(),
-			VideoID:      job.VideoID.Hex(),
-			Type:         string(job.Type),
-			Status:       string(job.Status),
-			Progress:     job.Progress,
-			ErrorMessage: job.ErrorMessage,
-			WorkerID:     job.WorkerID,
-			Payload:      job.Payload,
-			CreatedAt:    job.CreatedAt,
-			UpdatedAt:    job.UpdatedAt,
-			StartedAt:    job.StartedAt,
-			CompletedAt:  job.CompletedAt,
-		}
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"jobs":  jobResponses,
-		"count": len(jobResponses),
-	})
+	c.JSON(http.StatusOK, convertToJobListResponse(page, limit))
 }
 
-// GetActiveJobs returns all currently processing jobs
+// GetActiveJobs returns a page of the currently processing jobs, newest first
 func (h *JobHandler) GetActiveJobs(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	jobs, err := h.processingService.GetActiveJobs(ctx)
+	limit, cursor := pageParams(c)
+
+	page, err := h.processingService.GetActiveJobs(ctx, limit, cursor)
 	if err != nil {
 		h.logger.Error("Failed to get active jobs", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get active jobs"})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get active jobs"})
 		return
 	}
 
-	jobResponses := make([]JobResponse, len(jobs))
-	for i, job := range jobs {
+	c.JSON(http.StatusOK, convertToJobListResponse(page, limit))
+}
+
+func convertToJobListResponse(page *services.JobPage, limit int) JobListResponse {
+	jobResponses := make([]JobResponse, len(page.Jobs))
+	for i, job := range page.Jobs {
 		jobResponses[i] = JobResponse{
 			ID:           job.ID.Hex(),
 			VideoID:      job.VideoID.Hex(),
@@ -159,8 +154,11 @@ func (h *JobHandler) GetActiveJobs(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"jobs":  jobResponses,
-		"count": len(jobResponses),
-	})
+	return JobListResponse{
+		Jobs:       jobResponses,
+		Count:      len(jobResponses),
+		Total:      page.Total,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	}
 }
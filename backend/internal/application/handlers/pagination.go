@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// pageParams reads the page size and the opaque cursor of a paginated listing
+func pageParams(c *gin.Context) (int, string) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	return limit, c.Query("cursor")
+}
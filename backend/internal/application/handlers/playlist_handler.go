@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type PlaylistHandler struct {
+	playlistService *services.PlaylistService
+	logger          *zap.Logger
+}
+
+type CreatePlaylistRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+// UpdatePlaylistRequest changes playlist metadata; omitted fields are left unchanged
+type UpdatePlaylistRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility"`
+}
+
+type AddPlaylistVideoRequest struct {
+	VideoID  string `json:"video_id" binding:"required"`
+	Position *int   `json:"position"` // zero-based; appended when omitted
+}
+
+// ReorderPlaylistRequest sets a new order for the videos of a playlist at the given version
+type ReorderPlaylistRequest struct {
+	VideoIDs []string `json:"video_ids" binding:"required"`
+	Version  *int     `json:"version" binding:"required"`
+}
+
+type PlaylistResponse struct {
+	ID          string          `json:"id"`
+	Owner       string          `json:"owner"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Visibility  string          `json:"visibility"`
+	VideoIDs    []string        `json:"video_ids"`
+	VideoCount  int             `json:"video_count"`
+	Version     int             `json:"version"`
+	Videos      []VideoResponse `json:"videos,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type PlaylistListResponse struct {
+	Playlists  []PlaylistResponse `json:"playlists"`
+	Total      int64              `json:"total"`
+	Limit      int                `json:"limit"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+func NewPlaylistHandler(playlistService *services.PlaylistService, logger *zap.Logger) *PlaylistHandler {
+	return &PlaylistHandler{
+		playlistService: playlistService,
+		logger:          logger,
+	}
+}
+
+// CreatePlaylist creates an empty playlist owned by the caller
+func (h *PlaylistHandler) CreatePlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var req CreatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	playlist, err := h.playlistService.CreatePlaylist(ctx, services.CreatePlaylistInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Visibility:  entities.Visibility(req.Visibility),
+	})
+	if err != nil {
+		h.logger.Error("Failed to create playlist", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, convertToPlaylistResponse(playlist, nil))
+}
+
+// GetPlaylists returns a page of playlists, optionally of one owner (a user ID or "me")
+func (h *PlaylistHandler) GetPlaylists(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	owner := c.Query("owner")
+	if owner == "me" {
+		identity := services.IdentityFromContext(c.Request.Context())
+		if identity == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "owner=me requires authentication"})
+			return
+		}
+		owner = identity.UserID()
+	}
+
+	page, err := h.playlistService.ListPlaylists(ctx, owner, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get playlists", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get playlists"})
+		return
+	}
+
+	playlistResponses := make([]PlaylistResponse, len(page.Playlists))
+	for i, playlist := range page.Playlists {
+		playlistResponses[i] = convertToPlaylistResponse(playlist, nil)
+	}
+
+	c.JSON(http.StatusOK, PlaylistListResponse{
+		Playlists:  playlistResponses,
+		Total:      page.Total,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// GetPlaylist returns a playlist with the videos in it the caller may watch
+func (h *PlaylistHandler) GetPlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	playlist, videos, err := h.playlistService.GetPlaylist(ctx, playlistID)
+	if err != nil {
+		h.logger.Error("Failed to get playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get playlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToPlaylistResponse(playlist, videos))
+}
+
+// UpdatePlaylist changes the title, description or visibility of a playlist
+func (h *PlaylistHandler) UpdatePlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	input := services.UpdatePlaylistInput{Title: req.Title, Description: req.Description}
+	if req.Visibility != nil {
+		visibility := entities.Visibility(*req.Visibility)
+		input.Visibility = &visibility
+	}
+
+	playlist, err := h.playlistService.UpdatePlaylist(ctx, playlistID, input)
+	if err != nil {
+		h.logger.Error("Failed to update playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToPlaylistResponse(playlist, nil))
+}
+
+// DeletePlaylist deletes a playlist without touching its videos
+func (h *PlaylistHandler) DeletePlaylist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.playlistService.DeletePlaylist(ctx, playlistID); err != nil {
+		h.logger.Error("Failed to delete playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddVideo inserts a video into a playlist
+func (h *PlaylistHandler) AddVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var req AddPlaylistVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	videoID, err := primitive.ObjectIDFromHex(req.VideoID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	playlist, err := h.playlistService.AddVideo(ctx, playlistID, videoID, req.Position)
+	if err != nil {
+		h.logger.Error("Failed to add video to playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToPlaylistResponse(playlist, nil))
+}
+
+// RemoveVideo takes a video out of a playlist
+func (h *PlaylistHandler) RemoveVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("videoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	playlist, err := h.playlistService.RemoveVideo(ctx, playlistID, videoID)
+	if err != nil {
+		h.logger.Error("Failed to remove video from playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToPlaylistResponse(playlist, nil))
+}
+
+// ReorderVideos sets a new order for the videos of a playlist. A stale version is rejected with 409.
+func (h *PlaylistHandler) ReorderVideos(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var req ReorderPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	videoIDs := make([]primitive.ObjectID, len(req.VideoIDs))
+	for i, id := range req.VideoIDs {
+		videoID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID: " + id})
+			return
+		}
+		videoIDs[i] = videoID
+	}
+
+	playlist, err := h.playlistService.ReorderVideos(ctx, playlistID, *req.Version, videoIDs)
+	if err != nil {
+		h.logger.Warn("Failed to reorder playlist", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToPlaylistResponse(playlist, nil))
+}
+
+// GetNextVideo returns the video to autoplay after the one given in ?after=, or the first one
+// without it. It responds with 204 when the playlist has no further playable video.
+func (h *PlaylistHandler) GetNextVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var after *primitive.ObjectID
+	if value := c.Query("after"); value != "" {
+		videoID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+			return
+		}
+		after = &videoID
+	}
+
+	video, err := h.playlistService.NextVideo(ctx, playlistID, after)
+	if err != nil {
+		h.logger.Error("Failed to get next playlist video", zap.String("playlist_id", playlistID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	if video == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoResponse(video))
+}
+
+// parsePlaylistID reads the playlist ID path parameter, responding with 400 when it is malformed
+func parsePlaylistID(c *gin.Context) (primitive.ObjectID, bool) {
+	playlistID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return primitive.NilObjectID, false
+	}
+	return playlistID, true
+}
+
+func convertToPlaylistResponse(playlist *entities.Playlist, videos []*entities.Video) PlaylistResponse {
+	videoIDs := make([]string, len(playlist.VideoIDs))
+	for i, id := range playlist.VideoIDs {
+		videoIDs[i] = id.Hex()
+	}
+
+	response := PlaylistResponse{
+		ID:          playlist.ID.Hex(),
+		Owner:       playlist.Owner,
+		Title:       playlist.Title,
+		Description: playlist.Description,
+		Visibility:  string(playlist.Visibility),
+		VideoIDs:    videoIDs,
+		VideoCount:  len(videoIDs),
+		Version:     playlist.Version,
+		CreatedAt:   playlist.CreatedAt,
+		UpdatedAt:   playlist.UpdatedAt,
+	}
+
+	if videos != nil {
+		response.Videos = make([]VideoResponse, len(videos))
+		for i, video := range videos {
+			response.Videos[i] = convertToVideoResponse(video)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type ReactionHandler struct {
+	reactionService *services.ReactionService
+	logger          *zap.Logger
+}
+
+type SetReactionRequest struct {
+	Type string `json:"type" binding:"required"` // "like" or "dislike"
+}
+
+type ReactionResponse struct {
+	VideoID    string `json:"video_id"`
+	Likes      int64  `json:"likes"`
+	Dislikes   int64  `json:"dislikes"`
+	MyReaction string `json:"my_reaction,omitempty"`
+}
+
+func NewReactionHandler(reactionService *services.ReactionService, logger *zap.Logger) *ReactionHandler {
+	return &ReactionHandler{
+		reactionService: reactionService,
+		logger:          logger,
+	}
+}
+
+// GetReaction returns the reaction counters of a video and the caller's own reaction
+func (h *ReactionHandler) GetReaction(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	summary, err := h.reactionService.GetReactions(ctx, videoID)
+	if err != nil {
+		h.logger.Error("Failed to get reactions", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToReactionResponse(videoID, summary))
+}
+
+// SetReaction likes or dislikes a video. Sending the same reaction again has no further effect.
+func (h *ReactionHandler) SetReaction(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req SetReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	summary, err := h.reactionService.SetReaction(ctx, videoID, entities.ReactionType(req.Type))
+	if err != nil {
+		h.logger.Warn("Failed to set reaction", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToReactionResponse(videoID, summary))
+}
+
+// RemoveReaction withdraws the caller's reaction to a video. Removing a missing reaction succeeds.
+func (h *ReactionHandler) RemoveReaction(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	summary, err := h.reactionService.RemoveReaction(ctx, videoID)
+	if err != nil {
+		h.logger.Warn("Failed to remove reaction", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToReactionResponse(videoID, summary))
+}
+
+func convertToReactionResponse(videoID primitive.ObjectID, summary *services.ReactionSummary) ReactionResponse {
+	return ReactionResponse{
+		VideoID:    videoID.Hex(),
+		Likes:      summary.Likes,
+		Dislikes:   summary.Dislikes,
+		MyReaction: string(summary.Mine),
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type RecommendationHandler struct {
+	recommendationService *services.RecommendationService
+	logger                *zap.Logger
+}
+
+func NewRecommendationHandler(recommendationService *services.RecommendationService, logger *zap.Logger) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+		logger:                logger,
+	}
+}
+
+// GetTrending returns the videos trending right now, best first
+func (h *RecommendationHandler) GetTrending(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, _ := pageParams(c)
+
+	page, err := h.recommendationService.Trending(ctx, limit)
+	if err != nil {
+		h.logger.Error("Failed to get trending videos", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get trending videos"})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoListResponse(page, limit))
+}
+
+// GetRelated returns videos related to a video, best match first
+func (h *RecommendationHandler) GetRelated(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	limit, _ := pageParams(c)
+
+	page, err := h.recommendationService.Related(ctx, videoID, limit)
+	if err != nil {
+		h.logger.Error("Failed to get related videos", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoListResponse(page, limit))
+}
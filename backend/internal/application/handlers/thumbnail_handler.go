@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type ThumbnailHandler struct {
+	thumbnailService *services.ThumbnailService
+	logger           *zap.Logger
+}
+
+// SelectThumbnailRequest names the thumbnail to show for a video, one of its thumbnails or its
+// custom_thumbnail; an empty name restores the default
+type SelectThumbnailRequest struct {
+	Thumbnail string `json:"thumbnail"`
+}
+
+func NewThumbnailHandler(thumbnailService *services.ThumbnailService, logger *zap.Logger) *ThumbnailHandler {
+	return &ThumbnailHandler{
+		thumbnailService: thumbnailService,
+		logger:           logger,
+	}
+}
+
+// SelectThumbnail chooses the primary thumbnail of a video
+func (h *ThumbnailHandler) SelectThumbnail(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req SelectThumbnailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	video, err := h.thumbnailService.SelectThumbnail(ctx, videoID, req.Thumbnail)
+	if err != nil {
+		h.logger.Error("Failed to select thumbnail", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoResponse(video))
+}
+
+// UploadThumbnail stores the JPEG or PNG image in the "image" form field as the video's custom thumbnail
+func (h *ThumbnailHandler) UploadThumbnail(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No image file provided"})
+		return
+	}
+	defer file.Close()
+
+	// Reading one byte past the limit lets the service reject oversized files
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxThumbnailFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image"})
+		return
+	}
+
+	video, err := h.thumbnailService.UploadCustomThumbnail(ctx, videoID, data)
+	if err != nil {
+		h.logger.Error("Failed to upload thumbnail", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoResponse(video))
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type UserHandler struct {
+	quotaService *services.QuotaService
+	logger       *zap.Logger
+}
+
+type UsageResponse struct {
+	UserID          string                `json:"user_id"`
+	OriginalsBytes  int64                 `json:"originals_bytes"`
+	RenditionsBytes int64                 `json:"renditions_bytes"`
+	ThumbnailsBytes int64                 `json:"thumbnails_bytes"`
+	CaptionsBytes   int64                 `json:"captions_bytes"`
+	TotalBytes      int64                 `json:"total_bytes"`
+	VideoCount      int64                 `json:"video_count"`
+	VideosToday     int64                 `json:"videos_today"`
+	Limits          entities.UploadLimits `json:"limits"`
+}
+
+func NewUserHandler(quotaService *services.QuotaService, logger *zap.Logger) *UserHandler {
+	return &UserHandler{
+		quotaService: quotaService,
+		logger:       logger,
+	}
+}
+
+// GetUsage reports storage consumption and upload limits for a user
+func (h *UserHandler) GetUsage(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := c.Param("id")
+	if userID == "me" {
+		userID = services.IdentityFromContext(c.Request.Context()).UserID()
+	}
+
+	usage, limits, err := h.quotaService.GetUsage(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get storage usage", zap.String("user_id", userID), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get storage usage"})
+		return
+	}
+
+	videosToday, err := h.quotaService.CountUploadsToday(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to count uploads", zap.String("user_id", userID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get storage usage"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UsageResponse{
+		UserID:          userID,
+		OriginalsBytes:  usage.OriginalsBytes,
+		RenditionsBytes: usage.RenditionsBytes,
+		ThumbnailsBytes: usage.ThumbnailsBytes,
+		CaptionsBytes:   usage.CaptionsBytes,
+		TotalBytes:      usage.TotalBytes(),
+		VideoCount:      usage.VideoCount,
+		VideosToday:     videosToday,
+		Limits:          limits,
+	})
+}
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"youtube-backend/internal/domain/entities"
@@ -17,10 +21,15 @@ import (
 	"go.uber.org/zap"
 )
 
+// playbackSessionCookie identifies the browser session that playback tokens are bound to
+const playbackSessionCookie = "vh_playback_session"
+
 type VideoHandler struct {
-	videoService *services.VideoService
-	minioClient  *storage.MinIOClient
-	logger       *zap.Logger
+	videoService    *services.VideoService
+	playbackService *services.PlaybackService
+	historyService  *services.HistoryService
+	minioClient     *storage.MinIOClient
+	logger          *zap.Logger
 }
 
 type UploadResponse struct {
@@ -29,38 +38,87 @@ type UploadResponse struct {
 }
 
 type VideoListResponse struct {
-	Videos []VideoResponse `json:"videos"`
-	Total  int64           `json:"total"`
-	Page   int             `json:"page"`
-	Limit  int             `json:"limit"`
+	Videos     []VideoResponse `json:"videos"`
+	Total      int64           `json:"total"`
+	Limit      int             `json:"limit"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 type VideoResponse struct {
 	ID               string                `json:"id"`
 	Title            string                `json:"title"`
 	Description      string                `json:"description"`
+	Tags             []string              `json:"tags"`
+	Category         string                `json:"category,omitempty"`
 	UploadedBy       string                `json:"uploaded_by"`
+	ChannelID        string                `json:"channel_id,omitempty"`
+	Visibility       string                `json:"visibility"`
+	PublishAt        *time.Time            `json:"publish_at,omitempty"`
+	Encrypted        bool                  `json:"encrypted"`
 	OriginalFilename string                `json:"original_filename"`
 	Duration         float64               `json:"duration"`
 	Size             int64                 `json:"size"`
 	Status           string                `json:"status"`
 	Formats          []VideoFormatResponse `json:"formats"`
 	Thumbnails       []string              `json:"thumbnails"`
+	PrimaryThumbnail string                `json:"primary_thumbnail,omitempty"`
+	CustomThumbnail  string                `json:"custom_thumbnail,omitempty"`
+	Captions         []CaptionResponse     `json:"captions"`
+	Chapters         []ChapterResponse     `json:"chapters"`
+	ChaptersSource   string                `json:"chapters_source,omitempty"` // "manual" or "description"
+	Revision         int                   `json:"revision"`
+	Revisions        []RevisionResponse    `json:"revisions"`
+	PendingRevision  int                   `json:"pending_revision,omitempty"`
+	PendingError     string                `json:"pending_error,omitempty"`
+	DeletedAt        *time.Time            `json:"deleted_at,omitempty"`
+	ViewCount        int64                 `json:"view_count"`
+	LikeCount        int64                 `json:"like_count"`
+	DislikeCount     int64                 `json:"dislike_count"`
+	ResumePosition   *float64              `json:"resume_position,omitempty"` // where the caller left off, in seconds
 	CreatedAt        time.Time             `json:"created_at"`
 	UpdatedAt        time.Time             `json:"updated_at"`
 }
 
+// UpdateVideoRequest changes video metadata; omitted fields are left unchanged
+type UpdateVideoRequest struct {
+	Title       *string  `json:"title"`
+	Description *string  `json:"description"`
+	Tags        []string `json:"tags"`
+	Category    *string  `json:"category"` // empty string removes the category
+	ChannelID   *string  `json:"channel_id"`
+	Visibility  *string  `json:"visibility"`
+	PublishAt   *string  `json:"publish_at"` // RFC 3339; empty string removes the scheduled release
+}
+
+type RevisionResponse struct {
+	Revision         int       `json:"revision"`
+	OriginalFilename string    `json:"original_filename"`
+	Size             int64     `json:"size"`
+	ReplacedAt       time.Time `json:"replaced_at"`
+}
+
+type PlaybackResponse struct {
+	VideoID      string            `json:"video_id"`
+	Token        string            `json:"token"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+	StreamURLs   map[string]string `json:"stream_urls"`
+	HLSURL       string            `json:"hls_url,omitempty"`
+	ThumbnailURL string            `json:"thumbnail_url,omitempty"`
+}
+
 type VideoFormatResponse struct {
 	Quality  string `json:"quality"`
 	Filename string `json:"filename"`
 	Size     int64  `json:"size"`
 }
 
-func NewVideoHandler(videoService *services.VideoService, minioClient *storage.MinIOClient, logger *zap.Logger) *VideoHandler {
+func NewVideoHandler(videoService *services.VideoService, playbackService *services.PlaybackService, historyService *services.HistoryService, minioClient *storage.MinIOClient, logger *zap.Logger) *VideoHandler {
 	return &VideoHandler{
-		videoService: videoService,
-		minioClient:  minioClient,
-		logger:       logger,
+		videoService:    videoService,
+		playbackService: playbackService,
+		historyService:  historyService,
+		minioClient:     minioClient,
+		logger:          logger,
 	}
 }
 
@@ -80,15 +138,23 @@ func (h *VideoHandler) UploadVideo(c *gin.Context) {
 	// Get form fields
 	title := c.PostForm("title")
 	description := c.PostForm("description")
-	uploadedBy := c.PostForm("uploaded_by")
+	uploadedBy := services.IdentityFromContext(c.Request.Context()).UserID()
 
 	if title == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Title is required"})
 		return
 	}
 
-	if uploadedBy == "" {
-		uploadedBy = "anonymous" // Default user
+	publishAt, err := parsePublishAt(c.PostForm("publish_at"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	channelID, err := parseChannelID(c.PostForm("channel_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	// Get uploaded file
@@ -107,19 +173,28 @@ func (h *VideoHandler) UploadVideo(c *gin.Context) {
 	}
 
 	// Create video record
-	video, err := h.videoService.CreateVideo(ctx, title, description, uploadedBy, header.Filename, header.Size)
+	video, err := h.videoService.CreateVideo(ctx, services.CreateVideoInput{
+		Title:            title,
+		Description:      description,
+		UploadedBy:       uploadedBy,
+		ChannelID:        channelID,
+		OriginalFilename: header.Filename,
+		Size:             header.Size,
+		Tags:             splitTags(c.PostForm("tags")),
+		Category:         c.PostForm("category"),
+		Visibility:       entities.Visibility(c.PostForm("visibility")),
+		PublishAt:        publishAt,
+		Encrypted:        c.PostForm("encrypt") == "true",
+	})
 	if err != nil {
 		h.logger.Error("Failed to create video record", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Generate unique filename for storage
-	ext := filepath.Ext(header.Filename)
-	objectName := "videos/original/" + video.ID.Hex() + ext
-
 	// Upload file to MinIO
-	err = h.minioClient.UploadFile(ctx, objectName, file, header.Size, "video/"+ext[1:])
+	ext := filepath.Ext(header.Filename)
+	err = h.minioClient.UploadFile(ctx, video.OriginalObjectName(), file, header.Size, "video/"+ext[1:])
 	if err != nil {
 		h.logger.Error("Failed to upload file to storage", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload file"})
@@ -144,46 +219,102 @@ func (h *VideoHandler) UploadVideo(c *gin.Context) {
 	})
 }
 
-// GetVideos returns a paginated list of videos
-func (h *VideoHandler) GetVideos(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+// ReplaceSource uploads a new original for an existing video and re-runs processing.
+// The video keeps its ID and current renditions until the new ones are ready.
+func (h *VideoHandler) ReplaceSource(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	// Parse query parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+		h.logger.Error("Failed to parse multipart form", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form data"})
+		return
 	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+
+	file, header, err := c.Request.FormFile("video")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No video file provided"})
+		return
 	}
+	defer file.Close()
 
-	offset := (page - 1) * limit
+	if header.Size == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Empty file"})
+		return
+	}
 
-	// Get videos
-	videos, err := h.videoService.ListVideos(ctx, limit, offset)
+	// Validate before uploading so rejected replacements don't leave objects behind
+	video, err := h.videoService.CheckSourceReplacement(ctx, objectID, header.Filename, header.Size)
 	if err != nil {
-		h.logger.Error("Failed to get videos", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get videos"})
+		h.logger.Error("Source replacement rejected", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Convert to response format
-	videoResponses := make([]VideoResponse, len(videos))
-	for i, video := range videos {
-		videoResponses[i] = h.convertToVideoResponse(video)
+	ext := filepath.Ext(header.Filename)
+	objectName := video.NextSourceObjectName(header.Filename)
+	if err := h.minioClient.UploadFile(ctx, objectName, file, header.Size, "video/"+ext[1:]); err != nil {
+		h.logger.Error("Failed to upload file to storage", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload file"})
+		return
 	}
 
-	c.JSON(http.StatusOK, VideoListResponse{
-		Videos: videoResponses,
-		Total:  int64(len(videos)), // TODO: Implement proper count
-		Page:   page,
-		Limit:  limit,
+	video, err = h.videoService.ReplaceSource(ctx, objectID, header.Filename, header.Size)
+	if err != nil {
+		h.logger.Error("Failed to replace video source", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		if deleteErr := h.minioClient.DeleteFile(ctx, objectName); deleteErr != nil {
+			h.logger.Warn("Failed to remove orphaned source", zap.String("object", objectName), zap.Error(deleteErr))
+		}
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("Video source replaced",
+		zap.String("video_id", video.ID.Hex()),
+		zap.Int("revision", video.Revision),
+		zap.String("filename", header.Filename))
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"video_id": video.ID.Hex(),
+		"revision": video.Revision,
+		"message":  "Source replaced and reprocessing started",
 	})
 }
 
+// GetVideos returns a page of videos, optionally searched with q and narrowed by filters
+func (h *VideoHandler) GetVideos(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	query, err := parseVideoQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get videos
+	page, err := h.videoService.ListVideos(ctx, query, limit, cursor)
+	if errors.Is(err, services.ErrInvalidInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get videos", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get videos"})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoListResponse(page, limit))
+}
+
 // GetVideo returns a specific video by ID
 func (h *VideoHandler) GetVideo(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
@@ -208,7 +339,137 @@ func (h *VideoHandler) GetVideo(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, h.convertToVideoResponse(video))
+	response := convertToVideoResponse(video)
+
+	// Resuming is a convenience; the video is still served when history is unavailable
+	progress, err := h.historyService.GetProgress(ctx, objectID)
+	if err != nil {
+		h.logger.Warn("Failed to get watch progress", zap.String("video_id", videoID), zap.Error(err))
+	} else if progress != nil {
+		resumePosition := progress.ResumePosition()
+		response.ResumePosition = &resumePosition
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateVideo changes the title, description, tags, category, channel or visibility of a video
+func (h *VideoHandler) UpdateVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req UpdateVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	input := services.UpdateVideoInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Category:    req.Category,
+	}
+	if req.ChannelID != nil {
+		channelID, err := primitive.ObjectIDFromHex(*req.ChannelID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel ID"})
+			return
+		}
+		input.ChannelID = &channelID
+	}
+	if req.Visibility != nil {
+		visibility := entities.Visibility(*req.Visibility)
+		input.Visibility = &visibility
+	}
+	if req.PublishAt != nil {
+		publishAt, err := parsePublishAt(*req.PublishAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if publishAt == nil {
+			publishAt = &time.Time{}
+		}
+		input.PublishAt = publishAt
+	}
+
+	video, err := h.videoService.UpdateVideo(ctx, objectID, input)
+	if err != nil {
+		h.logger.Error("Failed to update video", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoResponse(video))
+}
+
+// DeleteVideo moves a video into the trash; its files are removed once the retention window has passed
+func (h *VideoHandler) DeleteVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	if err := h.videoService.DeleteVideo(ctx, objectID); err != nil {
+		h.logger.Error("Failed to delete video", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("Video moved to trash", zap.String("video_id", objectID.Hex()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreVideo takes a video out of the trash
+func (h *VideoHandler) RestoreVideo(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	video, err := h.videoService.RestoreVideo(ctx, objectID)
+	if err != nil {
+		h.logger.Error("Failed to restore video", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("Video restored", zap.String("video_id", video.ID.Hex()))
+
+	c.JSON(http.StatusOK, convertToVideoResponse(video))
+}
+
+// GetTrash returns a page of the caller's trashed videos
+func (h *VideoHandler) GetTrash(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	limit, cursor := pageParams(c)
+
+	page, err := h.videoService.ListTrash(ctx, limit, cursor)
+	if err != nil {
+		h.logger.Error("Failed to get trash", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to get trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoListResponse(page, limit))
 }
 
 // StreamVideo handles video streaming
@@ -225,18 +486,23 @@ func (h *VideoHandler) StreamVideo(c *gin.Context) {
 		return
 	}
 
-	// Get video record
-	video, err := h.videoService.GetVideo(ctx, objectID)
+	// Get video record granted by the playback token
+	video, err := h.playbackService.GetVideoWithToken(ctx, objectID, c.Query("token"), c.ClientIP(), playbackSession(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Video not found"})
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Invalid or expired playback token"})
+		return
+	}
+
+	// Progressive files are not encrypted, so protected videos are only served over HLS
+	if video.Encrypted {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Encrypted videos can only be streamed over HLS"})
 		return
 	}
 
 	// Determine file path based on quality
 	var objectName string
 	if quality == "original" {
-		ext := filepath.Ext(video.OriginalFilename)
-		objectName = "videos/original/" + video.ID.Hex() + ext
+		objectName = video.OriginalObjectName()
 	} else {
 		// Look for specific quality format
 		found := false
@@ -265,7 +531,7 @@ func (h *VideoHandler) StreamVideo(c *gin.Context) {
 	// Set appropriate headers
 	c.Header("Content-Type", "video/mp4")
 	c.Header("Accept-Ranges", "bytes")
-	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("Cache-Control", cacheControl(video))
 
 	// Stream the file
 	_, err = io.Copy(c.Writer, object)
@@ -274,24 +540,91 @@ func (h *VideoHandler) StreamVideo(c *gin.Context) {
 	}
 }
 
+// GetPlayback issues a signed, expiring token and the URLs to play a video with it
+func (h *VideoHandler) GetPlayback(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	sessionID := playbackSession(c)
+	if sessionID == "" && h.playbackService.BindsSession() {
+		sessionID, err = services.NewPlaybackSessionID()
+		if err != nil {
+			h.logger.Error("Failed to create playback session", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue playback token"})
+			return
+		}
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(playbackSessionCookie, sessionID, 0, "/", "", c.Request.TLS != nil, true)
+	}
+
+	grant, err := h.playbackService.IssueToken(ctx, objectID, c.ClientIP(), sessionID)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	video := grant.Video
+	query := "token=" + url.QueryEscape(grant.Token)
+	basePath := "/api/v1/videos/" + video.ID.Hex()
+
+	response := PlaybackResponse{
+		VideoID:    video.ID.Hex(),
+		Token:      grant.Token,
+		ExpiresAt:  grant.ExpiresAt,
+		StreamURLs: map[string]string{},
+	}
+	if !video.Encrypted {
+		response.StreamURLs["original"] = basePath + "/stream?quality=original&" + query
+	}
+	for _, format := range video.Formats {
+		if !video.Encrypted {
+			response.StreamURLs[format.Quality] = basePath + "/stream?quality=" + url.QueryEscape(format.Quality) + "&" + query
+		}
+		if format.Playlist != "" {
+			response.HLSURL = basePath + "/hls/master.m3u8?" + query
+		}
+	}
+	if video.PrimaryThumbnailObject() != "" {
+		response.ThumbnailURL = basePath + "/thumbnail?" + query
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ProcessVideo manually triggers video processing
 func (h *VideoHandler) ProcessVideo(c *gin.Context) {
-	videoID := c.Param("id")
-	if videoID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Video ID is required"})
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	video, err := h.videoService.ReprocessVideo(ctx, objectID)
+	if err != nil {
+		h.logger.Error("Failed to trigger processing", zap.String("video_id", objectID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
 		return
 	}
 
-	// TODO: Implement manual processing trigger
-	h.logger.Info("Manual processing triggered", zap.String("video_id", videoID))
+	h.logger.Info("Manual processing triggered", zap.String("video_id", video.ID.Hex()))
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "Processing triggered",
-		"video_id": videoID,
+		"video_id": video.ID.Hex(),
 	})
 }
 
-// GetThumbnail serves video thumbnails from MinIO storage
+// GetThumbnail serves video thumbnails from MinIO storage: the video's primary thumbnail, or the one named
+// by the candidate query parameter, in the size query parameter (small, medium or large, the default)
 func (h *VideoHandler) GetThumbnail(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
@@ -308,25 +641,46 @@ func (h *VideoHandler) GetThumbnail(c *gin.Context) {
 		return
 	}
 
-	// Get video record to check if thumbnails exist
-	video, err := h.videoService.GetVideo(ctx, objectID)
+	// Get video record to check if thumbnails exist. A playback token grants access to
+	// hidden videos; without one the caller must be allowed to view the video.
+	var video *entities.Video
+	if token := c.Query("token"); token != "" {
+		video, err = h.playbackService.GetVideoWithToken(ctx, objectID, token, c.ClientIP(), playbackSession(c))
+	} else {
+		video, err = h.videoService.GetVideo(ctx, objectID)
+	}
 	if err != nil {
 		h.logger.Error("Failed to get video", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Video not found"})
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Video not found"})
 		return
 	}
 
+	size := entities.ThumbnailSize(c.DefaultQuery("size", string(entities.ThumbnailLarge)))
+	if !size.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid thumbnail size"})
+		return
+	}
+
+	thumbnailFilename := video.PrimaryThumbnailObject()
+	if candidate := c.Query("candidate"); candidate != "" {
+		if !video.HasThumbnail(candidate) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thumbnail not found"})
+			return
+		}
+		thumbnailFilename = candidate
+	}
+
 	// Check if video has thumbnails
-	if len(video.Thumbnails) == 0 {
+	if thumbnailFilename == "" {
 		c.JSON(http.StatusNotFound, gin.H{"error": "No thumbnail available for this video"})
 		return
 	}
 
-	// Use the first thumbnail (typically there's only one)
-	thumbnailFilename := video.Thumbnails[0]
-
-	// Download thumbnail from MinIO
-	thumbnailObject, err := h.minioClient.DownloadThumbnail(ctx, thumbnailFilename)
+	// Download thumbnail from MinIO. Thumbnails generated before sizes existed are served as they are.
+	thumbnailObject, err := h.downloadThumbnail(ctx, entities.ThumbnailObjectName(thumbnailFilename, size))
+	if err != nil && size != entities.ThumbnailLarge {
+		thumbnailObject, err = h.downloadThumbnail(ctx, thumbnailFilename)
+	}
 	if err != nil {
 		h.logger.Error("Failed to download thumbnail",
 			zap.String("video_id", videoID),
@@ -339,7 +693,7 @@ func (h *VideoHandler) GetThumbnail(c *gin.Context) {
 
 	// Set appropriate headers for image response
 	c.Header("Content-Type", "image/jpeg")
-	c.Header("Cache-Control", "public, max-age=3600") // Cache for 1 hour
+	c.Header("Cache-Control", cacheControl(video)) // Cache for 1 hour
 	c.Header("Content-Disposition", "inline")
 
 	// Stream the thumbnail
@@ -356,8 +710,137 @@ func (h *VideoHandler) GetThumbnail(c *gin.Context) {
 		zap.String("thumbnail", thumbnailFilename))
 }
 
+// downloadThumbnail opens a thumbnail, checking that it exists before anything is written to the response
+func (h *VideoHandler) downloadThumbnail(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := h.minioClient.DownloadThumbnail(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, err
+	}
+	return object, nil
+}
+
+// playbackSession returns the caller's playback session ID, if any
+func playbackSession(c *gin.Context) string {
+	sessionID, err := c.Cookie(playbackSessionCookie)
+	if err != nil {
+		return ""
+	}
+	return sessionID
+}
+
+// parseVideoQuery reads the search text and filters of a video listing from the query string
+func parseVideoQuery(c *gin.Context) (services.VideoQuery, error) {
+	query := services.VideoQuery{
+		Text:       c.Query("q"),
+		Status:     entities.VideoStatus(c.Query("status")),
+		UploadedBy: c.Query("uploader"),
+		Quality:    c.Query("quality"),
+		Tag:        c.Query("tag"),
+		Category:   c.Query("category"),
+	}
+
+	if value := c.Query("channel"); value != "" {
+		channelID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			return query, fmt.Errorf("channel must be a channel ID")
+		}
+		query.ChannelID = &channelID
+	}
+
+	if query.UploadedBy == "me" {
+		identity := services.IdentityFromContext(c.Request.Context())
+		if identity == nil {
+			return query, fmt.Errorf("uploader=me requires authentication")
+		}
+		query.UploadedBy = identity.UserID()
+	}
+
+	for param, target := range map[string]*float64{"min_duration": &query.MinDuration, "max_duration": &query.MaxDuration} {
+		if value := c.Query(param); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return query, fmt.Errorf("%s must be a number of seconds", param)
+			}
+			*target = parsed
+		}
+	}
+
+	for param, target := range map[string]**time.Time{"uploaded_after": &query.UploadedAfter, "uploaded_before": &query.UploadedBefore} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("%s must be an RFC 3339 timestamp", param)
+			}
+			*target = &parsed
+		}
+	}
+
+	return query, nil
+}
+
+// parsePublishAt parses an optional RFC 3339 release time from a form field
+func parsePublishAt(value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	publishAt, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("publish_at must be an RFC 3339 timestamp")
+	}
+	return &publishAt, nil
+}
+
+// parseChannelID parses an optional channel ID from a form field
+func parseChannelID(value string) (*primitive.ObjectID, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	channelID, err := primitive.ObjectIDFromHex(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("channel_id must be a channel ID")
+	}
+	return &channelID, nil
+}
+
+// cacheControl lets shared caches store media only for videos anyone can watch
+func cacheControl(video *entities.Video) string {
+	if video.IsWatchableByAnyone(time.Now()) {
+		return "public, max-age=3600"
+	}
+	return "private, max-age=3600"
+}
+
+// splitTags parses a comma separated tag list from a form field
+func splitTags(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// convertToVideoListResponse converts a page of videos to API response
+func convertToVideoListResponse(page *services.VideoPage, limit int) VideoListResponse {
+	videoResponses := make([]VideoResponse, len(page.Videos))
+	for i, video := range page.Videos {
+		videoResponses[i] = convertToVideoResponse(video)
+	}
+
+	return VideoListResponse{
+		Videos:     videoResponses,
+		Total:      page.Total,
+		Limit:      limit,
+		NextCursor: page.NextCursor,
+	}
+}
+
 // convertToVideoResponse converts domain entity to API response
-func (h *VideoHandler) convertToVideoResponse(video *entities.Video) VideoResponse {
+func convertToVideoResponse(video *entities.Video) VideoResponse {
 	formats := make([]VideoFormatResponse, len(video.Formats))
 	for i, format := range video.Formats {
 		formats[i] = VideoFormatResponse{
@@ -367,17 +850,63 @@ func (h *VideoHandler) convertToVideoResponse(video *entities.Video) VideoRespon
 		}
 	}
 
+	captions := make([]CaptionResponse, len(video.Captions))
+	for i, caption := range video.Captions {
+		captions[i] = convertToCaptionResponse(video.ID, caption)
+	}
+
+	chapters, chaptersSource := convertToChapterResponses(video)
+
+	var customThumbnail string
+	if video.CustomThumbnail != nil {
+		customThumbnail = video.CustomThumbnail.Object
+	}
+
+	revisions := make([]RevisionResponse, len(video.Revisions))
+	for i, revision := range video.Revisions {
+		revisions[i] = RevisionResponse{
+			Revision:         revision.Revision,
+			OriginalFilename: revision.OriginalFilename,
+			Size:             revision.Size,
+			ReplacedAt:       revision.ReplacedAt,
+		}
+	}
+
+	var channelID string
+	if !video.ChannelID.IsZero() {
+		channelID = video.ChannelID.Hex()
+	}
+
 	return VideoResponse{
 		ID:               video.ID.Hex(),
 		Title:            video.Title,
 		Description:      video.Description,
+		Tags:             video.Tags,
+		Category:         video.Category,
 		UploadedBy:       video.UploadedBy,
+		ChannelID:        channelID,
+		Visibility:       string(video.EffectiveVisibility()),
+		PublishAt:        video.PublishAt,
+		Encrypted:        video.Encrypted,
 		OriginalFilename: video.OriginalFilename,
 		Duration:         video.Duration,
 		Size:             video.Size,
 		Status:           string(video.Status),
 		Formats:          formats,
 		Thumbnails:       video.Thumbnails,
+		PrimaryThumbnail: video.PrimaryThumbnailObject(),
+		CustomThumbnail:  customThumbnail,
+		Captions:         captions,
+		Chapters:         chapters,
+		ChaptersSource:   string(chaptersSource),
+		Revision:         video.Revision,
+		Revisions:        revisions,
+		PendingRevision:  video.PendingRevision,
+		PendingError:     video.PendingError,
+		DeletedAt:        video.DeletedAt,
+		ViewCount:        video.ViewCount,
+		LikeCount:        video.LikeCount,
+		DislikeCount:     video.DislikeCount,
 		CreatedAt:        video.CreatedAt,
 		UpdatedAt:        video.UpdatedAt,
 	}
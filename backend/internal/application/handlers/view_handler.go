@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// analyticsDateLayout is the format of the from and to query parameters and of days in analytics responses
+const analyticsDateLayout = "2006-01-02"
+
+// defaultAnalyticsDays is the range of an analytics request without from
+const defaultAnalyticsDays = 28
+
+type ViewHandler struct {
+	viewService *services.ViewService
+	logger      *zap.Logger
+}
+
+// ViewEventRequest is a playback event sent by a player
+type ViewEventRequest struct {
+	Event    string  `json:"event" binding:"required"` // start, heartbeat or end
+	ViewID   string  `json:"view_id"`                  // from the start response; required for heartbeat and end
+	Position float64 `json:"position"`                 // playback position in seconds
+}
+
+type ViewStartResponse struct {
+	ViewID  string `json:"view_id"`
+	Counted bool   `json:"counted"`
+}
+
+type DailyViewsResponse struct {
+	Date      string  `json:"date"`
+	Views     int64   `json:"views"`
+	Plays     int64   `json:"plays"`
+	WatchTime float64 `json:"watch_time"`
+}
+
+type RetentionPointResponse struct {
+	Start float64 `json:"start"`
+	Plays int64   `json:"plays"`
+	Share float64 `json:"share"`
+}
+
+type VideoAnalyticsResponse struct {
+	VideoID          string                   `json:"video_id"`
+	TotalViews       int64                    `json:"total_views"`
+	From             string                   `json:"from"`
+	To               string                   `json:"to"`
+	Views            int64                    `json:"views"`
+	Plays            int64                    `json:"plays"`
+	WatchTime        float64                  `json:"watch_time"`
+	AverageWatchTime float64                  `json:"average_watch_time"`
+	Days             []DailyViewsResponse     `json:"days"`
+	Retention        []RetentionPointResponse `json:"retention"`
+}
+
+func NewViewHandler(viewService *services.ViewService, logger *zap.Logger) *ViewHandler {
+	return &ViewHandler{
+		viewService: viewService,
+		logger:      logger,
+	}
+}
+
+// RecordViewEvent ingests a start, heartbeat or end event from a player
+func (h *ViewHandler) RecordViewEvent(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	var req ViewEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	event := entities.ViewEventType(req.Event)
+	if !event.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Event must be start, heartbeat or end"})
+		return
+	}
+
+	if event == entities.ViewEventStart {
+		start, err := h.viewService.StartView(ctx, videoID, requestViewer(c), req.Position)
+		if err != nil {
+			h.logger.Warn("Failed to start view", zap.String("video_id", videoID.Hex()), zap.Error(err))
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, ViewStartResponse{ViewID: start.ViewID, Counted: start.Counted})
+		return
+	}
+
+	if req.ViewID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "view_id is required"})
+		return
+	}
+
+	if err := h.viewService.RecordProgress(ctx, videoID, req.ViewID, req.Position, event == entities.ViewEventEnd); err != nil {
+		h.logger.Warn("Failed to record view progress", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAnalytics returns views per day, watch time and audience retention of a video.
+// The range is given as from and to dates (YYYY-MM-DD, UTC) and defaults to the last 28 days.
+func (h *ViewHandler) GetAnalytics(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	videoID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid video ID"})
+		return
+	}
+
+	to := time.Now().UTC()
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(analyticsDateLayout, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date like 2024-01-31"})
+			return
+		}
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(analyticsDateLayout, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date like 2024-01-01"})
+			return
+		}
+	}
+
+	analytics, err := h.viewService.GetAnalytics(ctx, videoID, from, to)
+	if err != nil {
+		h.logger.Error("Failed to get analytics", zap.String("video_id", videoID.Hex()), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToVideoAnalyticsResponse(analytics))
+}
+
+func convertToVideoAnalyticsResponse(analytics *services.VideoAnalytics) VideoAnalyticsResponse {
+	days := make([]DailyViewsResponse, len(analytics.Days))
+	for i, day := range analytics.Days {
+		days[i] = DailyViewsResponse{
+			Date:      day.Day.Format(analyticsDateLayout),
+			Views:     day.Views,
+			Plays:     day.Plays,
+			WatchTime: day.WatchTime,
+		}
+	}
+
+	retention := make([]RetentionPointResponse, len(analytics.Retention))
+	for i, point := range analytics.Retention {
+		retention[i] = RetentionPointResponse{
+			Start: point.Start,
+			Plays: point.Plays,
+			Share: point.Share,
+		}
+	}
+
+	return VideoAnalyticsResponse{
+		VideoID:          analytics.VideoID.Hex(),
+		TotalViews:       analytics.TotalViews,
+		From:             analytics.From.Format(analyticsDateLayout),
+		To:               analytics.To.Format(analyticsDateLayout),
+		Views:            analytics.Views,
+		Plays:            analytics.Plays,
+		WatchTime:        analytics.WatchTime,
+		AverageWatchTime: analytics.AverageWatchTime,
+		Days:             days,
+		Retention:        retention,
+	}
+}
+
+// requestViewer describes the client of a view request. The address is the connecting address unless
+// the request came through one of the configured trusted proxies.
+func requestViewer(c *gin.Context) services.Viewer {
+	return services.Viewer{
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
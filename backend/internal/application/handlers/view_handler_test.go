@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestViewerIgnoresUntrustedForwardingHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		headers        map[string]string
+		wantIP         string
+	}{
+		{name: "no headers", wantIP: "203.0.113.7"},
+		{name: "spoofed X-Forwarded-For", headers: map[string]string{"X-Forwarded-For": "198.51.100.1"}, wantIP: "203.0.113.7"},
+		{name: "spoofed X-Real-IP", headers: map[string]string{"X-Real-IP": "198.51.100.2"}, wantIP: "203.0.113.7"},
+		{name: "trusted proxy", trustedProxies: []string{"203.0.113.0/24"}, headers: map[string]string{"X-Forwarded-For": "198.51.100.1"}, wantIP: "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			if err := router.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatalf("SetTrustedProxies() error = %v", err)
+			}
+
+			var viewer services.Viewer
+			router.POST("/views", func(c *gin.Context) {
+				viewer = requestViewer(c)
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/views", nil)
+			req.RemoteAddr = "203.0.113.7:51234"
+			req.Header.Set("User-Agent", "test-player")
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			want := services.Viewer{ClientIP: tt.wantIP, UserAgent: "test-player"}
+			if viewer != want {
+				t.Errorf("requestViewer() = %+v, want %+v", viewer, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// API key scopes
+const (
+	ScopeVideosRead  = "videos:read"
+	ScopeVideosWrite = "videos:write"
+	ScopeJobsRead    = "jobs:read"
+)
+
+// APIKeyScopes lists every scope that can be granted to an API key
+var APIKeyScopes = []string{ScopeVideosRead, ScopeVideosWrite, ScopeJobsRead}
+
+// APIKey is a long-lived credential for machine clients acting on behalf of a user.
+// Only a hash of the key is stored; Prefix identifies the key in listings.
+type APIKey struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name       string             `json:"name" bson:"name"`
+	Prefix     string             `json:"prefix" bson:"prefix"`
+	KeyHash    string             `json:"-" bson:"key_hash"`
+	Scopes     []string           `json:"scopes" bson:"scopes"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// NewAPIKey creates a new API key entity
+func NewAPIKey(userID primitive.ObjectID, name, prefix, keyHash string, scopes []string) *APIKey {
+	return &APIKey{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}
+}
+
+// HasScope checks if the key grants the given scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidAPIKeyScope checks if a scope can be granted to an API key
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Batch groups videos uploaded together so their processing can be tracked as one unit
+type Batch struct {
+	ID         primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	UploadedBy string               `json:"uploaded_by" bson:"uploaded_by"`
+	VideoIDs   []primitive.ObjectID `json:"video_ids" bson:"video_ids"`
+	CreatedAt  time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+// NewBatch creates a new batch entity
+func NewBatch(uploadedBy string, videoIDs []primitive.ObjectID) *Batch {
+	now := time.Now()
+	return &Batch{
+		ID:         primitive.NewObjectID(),
+		UploadedBy: uploadedBy,
+		VideoIDs:   videoIDs,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
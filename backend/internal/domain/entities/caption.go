@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const MaxCaptionLabelLength = 64 // in characters
+
+// languageTagPattern accepts BCP 47 tags such as "en", "pt-BR" or "zh-Hant"
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Caption is a WebVTT subtitle or closed caption track of a video, one per language
+type Caption struct {
+	Language   string    `json:"language" bson:"language"` // normalized BCP 47 tag, see NormalizeLanguage
+	Label      string    `json:"label" bson:"label"`       // name shown in player menus
+	Object     string    `json:"object" bson:"object"`     // WebVTT file in the videos bucket
+	Size       int64     `json:"size" bson:"size"`         // in bytes
+	Cues       int       `json:"cues" bson:"cues"`
+	UploadedAt time.Time `json:"uploaded_at" bson:"uploaded_at"`
+}
+
+// NormalizeLanguage canonicalizes the case of a BCP 47 tag: "PT-br" becomes "pt-BR", "zh-hant" becomes "zh-Hant".
+// It returns an empty string for malformed tags.
+func NormalizeLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if !languageTagPattern.MatchString(tag) {
+		return ""
+	}
+
+	parts := strings.Split(tag, "-")
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ValidateCaptionLabel checks that a label is short and free of control characters. Labels are written
+// into HLS master playlists, where a line break would start a new tag.
+func ValidateCaptionLabel(label string) error {
+	if utf8.RuneCountInString(label) > MaxCaptionLabelLength {
+		return fmt.Errorf("caption label is longer than %d characters", MaxCaptionLabelLength)
+	}
+	if strings.IndexFunc(label, unicode.IsControl) >= 0 {
+		return fmt.Errorf("caption label must not contain control characters")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCaptionLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		wantErr bool
+	}{
+		{name: "empty", label: ""},
+		{name: "plain", label: "English (CC)"},
+		{name: "quotes", label: `"Director's" commentary`},
+		{name: "longest allowed", label: strings.Repeat("é", MaxCaptionLabelLength)},
+		{name: "too long", label: strings.Repeat("a", MaxCaptionLabelLength+1), wantErr: true},
+		{name: "line feed", label: "English\n#EXT-X-STREAM-INF:BANDWIDTH=1", wantErr: true},
+		{name: "carriage return", label: "English\rx", wantErr: true},
+		{name: "tab", label: "English\tCC", wantErr: true},
+		{name: "next line control", label: "English\u0085x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCaptionLabel(tt.label)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCaptionLabel(%q) error = %v, wantErr %v", tt.label, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package entities
+
+// Category is an entry of the catalog taxonomy videos are filed under
+type Category struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Categories is the catalog taxonomy in display order
+var Categories = []Category{
+	{Slug: "autos-vehicles", Name: "Autos & Vehicles"},
+	{Slug: "comedy", Name: "Comedy"},
+	{Slug: "education", Name: "Education"},
+	{Slug: "entertainment", Name: "Entertainment"},
+	{Slug: "film-animation", Name: "Film & Animation"},
+	{Slug: "gaming", Name: "Gaming"},
+	{Slug: "howto-style", Name: "Howto & Style"},
+	{Slug: "music", Name: "Music"},
+	{Slug: "news-politics", Name: "News & Politics"},
+	{Slug: "nonprofits-activism", Name: "Nonprofits & Activism"},
+	{Slug: "people-blogs", Name: "People & Blogs"},
+	{Slug: "pets-animals", Name: "Pets & Animals"},
+	{Slug: "science-technology", Name: "Science & Technology"},
+	{Slug: "sports", Name: "Sports"},
+	{Slug: "travel-events", Name: "Travel & Events"},
+}
+
+// IsValidCategory checks if the slug names a category of the taxonomy
+func IsValidCategory(slug string) bool {
+	for _, category := range Categories {
+		if category.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryCount is the number of videos filed under a category
+type CategoryCount struct {
+	Category string `json:"category" bson:"_id"`
+	Count    int64  `json:"count" bson:"count"`
+}
+
+// TagCount is the number of videos carrying a tag
+type TagCount struct {
+	Tag   string `json:"tag" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
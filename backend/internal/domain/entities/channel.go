@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	minHandleLength = 3
+	maxHandleLength = 30
+)
+
+// ChannelImage names an image of a channel's profile
+type ChannelImage string
+
+const (
+	ChannelAvatar ChannelImage = "avatar"
+	ChannelBanner ChannelImage = "banner"
+)
+
+// IsValid checks if the image is one of the known kinds
+func (i ChannelImage) IsValid() bool {
+	return i == ChannelAvatar || i == ChannelBanner
+}
+
+// Channel is a user's public identity that videos are published under
+type Channel struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Owner           string             `json:"owner" bson:"owner"`
+	Handle          string             `json:"handle" bson:"handle"` // unique, see NormalizeHandle
+	Name            string             `json:"name" bson:"name"`
+	Description     string             `json:"description" bson:"description"`
+	AvatarObject    string             `json:"avatar_object,omitempty" bson:"avatar_object,omitempty"` // in the thumbnails bucket
+	BannerObject    string             `json:"banner_object,omitempty" bson:"banner_object,omitempty"` // in the thumbnails bucket
+	SubscriberCount int64              `json:"subscriber_count" bson:"subscriber_count"`               // maintained with $inc
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// NewChannel creates a new channel entity with a normalized handle
+func NewChannel(owner, handle, name, description string) *Channel {
+	now := time.Now()
+	return &Channel{
+		ID:          primitive.NewObjectID(),
+		Owner:       owner,
+		Handle:      NormalizeHandle(handle),
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ImageObject returns the stored object of a channel image, empty if none was uploaded
+func (c *Channel) ImageObject(image ChannelImage) string {
+	if image == ChannelBanner {
+		return c.BannerObject
+	}
+	return c.AvatarObject
+}
+
+// NormalizeHandle lowercases a handle and strips a leading '@'
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// IsValidHandle checks a normalized handle: 3 to 30 lowercase letters, digits, '.', '_' or '-',
+// starting with a letter or digit
+func IsValidHandle(handle string) bool {
+	if len(handle) < minHandleLength || len(handle) > maxHandleLength {
+		return false
+	}
+	for i, r := range handle {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case (r == '.' || r == '_' || r == '-') && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Subscription records that a user follows a channel
+type Subscription struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    string             `json:"user_id" bson:"user_id"`
+	ChannelID primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// NewSubscription creates a new subscription entity
+func NewSubscription(userID string, channelID primitive.ObjectID) *Subscription {
+	return &Subscription{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		ChannelID: channelID,
+		CreatedAt: time.Now(),
+	}
+}
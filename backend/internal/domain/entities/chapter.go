@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxChapters           = 100
+	MaxChapterTitleLength = 100 // in characters
+	// minDescriptionChapters is how many timestamp lines a description needs before they are read as chapters
+	minDescriptionChapters = 3
+)
+
+// chapterLinePattern matches a description line starting with a timestamp such as "0:00 Intro",
+// "(1:02:03) - Setup" or "- 12:30 | Wrap-up"
+var chapterLinePattern = regexp.MustCompile(`^\s*(?:[-*•]\s*)?[\[(]?((?:\d{1,2}:)?\d{1,2}:[0-5]\d)[\])]?\s*(?:[-–—:|]\s*)?(\S.*)$`)
+
+// ChapterSource tells where the chapters of a video come from
+type ChapterSource string
+
+const (
+	ChapterSourceManual      ChapterSource = "manual"      // set through the chapters API
+	ChapterSourceDescription ChapterSource = "description" // parsed from timestamp lines in the description
+)
+
+// Chapter is a titled section of a video. A chapter lasts until the next one starts, the last one until the end.
+type Chapter struct {
+	Start float64 `json:"start" bson:"start"` // in seconds
+	Title string  `json:"title" bson:"title"`
+}
+
+// ValidateChapters checks that chapters start at 0, are in ascending order and have titles. Chapters must
+// start before the end of the video unless its duration is not known yet (zero).
+func ValidateChapters(chapters []Chapter, duration float64) error {
+	if len(chapters) > MaxChapters {
+		return fmt.Errorf("a video can have at most %d chapters", MaxChapters)
+	}
+	for i, chapter := range chapters {
+		if i == 0 && chapter.Start != 0 {
+			return fmt.Errorf("the first chapter must start at 0")
+		}
+		if i > 0 && chapter.Start <= chapters[i-1].Start {
+			return fmt.Errorf("chapter %d must start after chapter %d", i+1, i)
+		}
+		if duration > 0 && chapter.Start >= duration {
+			return fmt.Errorf("chapter %d starts after the end of the video", i+1)
+		}
+		if strings.TrimSpace(chapter.Title) == "" {
+			return fmt.Errorf("chapter %d has no title", i+1)
+		}
+		if strings.ContainsAny(chapter.Title, "\r\n") {
+			return fmt.Errorf("chapter %d title must be a single line", i+1)
+		}
+		if utf8.RuneCountInString(chapter.Title) > MaxChapterTitleLength {
+			return fmt.Errorf("chapter %d title is longer than %d characters", i+1, MaxChapterTitleLength)
+		}
+	}
+	return nil
+}
+
+// ParseChapters reads chapters from the timestamp lines of a video description. Like the timestamps
+// people paste into descriptions elsewhere, they only count as chapters when there are at least three,
+// the first at 0:00, all in ascending order; otherwise ParseChapters returns nil.
+func ParseChapters(description string) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		match := chapterLinePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil {
+			continue
+		}
+		chapters = append(chapters, Chapter{Start: parseTimestamp(match[1]), Title: strings.TrimSpace(match[2])})
+	}
+
+	if len(chapters) < minDescriptionChapters || ValidateChapters(chapters, 0) != nil {
+		return nil
+	}
+	return chapters
+}
+
+// ResolveChapters returns the chapters of the video and where they come from: the ones set through the
+// API, or else the ones in its description. Chapters starting after the end of the video are dropped
+// from set chapters, while description chapters are ignored entirely unless they fit the video.
+func (v *Video) ResolveChapters() ([]Chapter, ChapterSource) {
+	if len(v.Chapters) > 0 {
+		chapters := v.Chapters
+		if v.Duration > 0 {
+			for len(chapters) > 0 && chapters[len(chapters)-1].Start >= v.Duration {
+				chapters = chapters[:len(chapters)-1]
+			}
+		}
+		return chapters, ChapterSourceManual
+	}
+
+	chapters := ParseChapters(v.Description)
+	if chapters == nil || ValidateChapters(chapters, v.Duration) != nil {
+		return nil, ""
+	}
+	return chapters, ChapterSourceDescription
+}
+
+// ChapterEnd returns where the i-th chapter ends: the start of the next one, or the duration for the last
+func ChapterEnd(chapters []Chapter, i int, duration float64) float64 {
+	if i+1 < len(chapters) {
+		return chapters[i+1].Start
+	}
+	return duration
+}
+
+// parseTimestamp converts [h:]m:ss to seconds
+func parseTimestamp(value string) float64 {
+	seconds := 0
+	for _, part := range strings.Split(value, ":") {
+		n, _ := strconv.Atoi(part)
+		seconds = seconds*60 + n
+	}
+	return float64(seconds)
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateChapters(t *testing.T) {
+	tooMany := make([]Chapter, MaxChapters+1)
+	for i := range tooMany {
+		tooMany[i] = Chapter{Start: float64(i * 10), Title: "Part"}
+	}
+
+	tests := []struct {
+		name     string
+		chapters []Chapter
+		duration float64
+		wantErr  string
+	}{
+		{name: "no chapters", chapters: nil, duration: 600},
+		{name: "valid", chapters: []Chapter{{0, "Intro"}, {30, "Setup"}, {120.5, "Wrap-up"}}, duration: 600},
+		{name: "unknown duration", chapters: []Chapter{{0, "Intro"}, {9000, "Late"}}, duration: 0},
+		{name: "first not at zero", chapters: []Chapter{{5, "Intro"}}, duration: 600, wantErr: "must start at 0"},
+		{name: "not ascending", chapters: []Chapter{{0, "Intro"}, {60, "B"}, {30, "C"}}, duration: 600, wantErr: "chapter 3 must start after chapter 2"},
+		{name: "same start", chapters: []Chapter{{0, "Intro"}, {0, "Again"}}, duration: 600, wantErr: "chapter 2 must start after chapter 1"},
+		{name: "starts at the end", chapters: []Chapter{{0, "Intro"}, {600, "Credits"}}, duration: 600, wantErr: "chapter 2 starts after the end"},
+		{name: "empty title", chapters: []Chapter{{0, "  "}}, duration: 600, wantErr: "chapter 1 has no title"},
+		{name: "multi-line title", chapters: []Chapter{{0, "Intro\nmore"}}, duration: 600, wantErr: "single line"},
+		{name: "longest title", chapters: []Chapter{{0, strings.Repeat("é", MaxChapterTitleLength)}}, duration: 600},
+		{name: "title too long", chapters: []Chapter{{0, strings.Repeat("é", MaxChapterTitleLength+1)}}, duration: 600, wantErr: "longer than"},
+		{name: "most chapters", chapters: tooMany[:MaxChapters], duration: 0},
+		{name: "too many chapters", chapters: tooMany, duration: 0, wantErr: "at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChapters(tt.chapters, tt.duration)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateChapters() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateChapters() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseChapters(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        []Chapter
+	}{
+		{
+			name:        "plain timestamps",
+			description: "My video\n\n0:00 Intro\n1:30 Setup\n12:05 Wrap-up\n",
+			want:        []Chapter{{0, "Intro"}, {90, "Setup"}, {725, "Wrap-up"}},
+		},
+		{
+			name:        "separators, brackets and list markers",
+			description: "(0:00) - Intro\n- [02:10] | Setup\n* 1:02:03: Deep dive\n• 1:10:00 — End",
+			want:        []Chapter{{0, "Intro"}, {130, "Setup"}, {3723, "Deep dive"}, {4200, "End"}},
+		},
+		{
+			name:        "CRLF line ends",
+			description: "0:00 Intro\r\n0:30 Middle\r\n1:00 End\r\n",
+			want:        []Chapter{{0, "Intro"}, {30, "Middle"}, {60, "End"}},
+		},
+		{
+			name:        "other lines are ignored",
+			description: "Chapters:\n0:00 Intro\nsee 5:00 for the good part\n1:00 Middle\nThanks!\n2:00 End",
+			want:        []Chapter{{0, "Intro"}, {60, "Middle"}, {120, "End"}},
+		},
+		{name: "fewer than three", description: "0:00 Intro\n1:00 End"},
+		{name: "first not at zero", description: "0:10 Intro\n1:00 Middle\n2:00 End"},
+		{name: "not ascending", description: "0:00 Intro\n2:00 Middle\n1:00 End"},
+		{name: "invalid seconds", description: "0:00 Intro\n1:60 Middle\n2:00 End"},
+		{name: "timestamp without title", description: "0:00\n1:00 Middle\n2:00 End"},
+		{name: "no description", description: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseChapters(tt.description)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseChapters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentStatus string
+
+const (
+	CommentStatusVisible CommentStatus = "visible"
+	CommentStatusDeleted CommentStatus = "deleted" // withdrawn by its author
+	CommentStatusRemoved CommentStatus = "removed" // taken down by a moderator
+)
+
+// Comment is a remark on a video. Top-level comments start a thread; replies point to
+// the top-level comment of their thread, so threads are one level deep.
+type Comment struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	VideoID    primitive.ObjectID  `json:"video_id" bson:"video_id"`
+	ParentID   *primitive.ObjectID `json:"parent_id,omitempty" bson:"parent_id"`
+	AuthorID   string              `json:"author_id" bson:"author_id"`
+	AuthorName string              `json:"author_name" bson:"author_name"` // username when the comment was written
+	Body       string              `json:"body" bson:"body"`
+	Status     CommentStatus       `json:"status" bson:"status"`
+	ReplyCount int64               `json:"reply_count" bson:"reply_count"`
+	RemovedBy  string              `json:"removed_by,omitempty" bson:"removed_by,omitempty"`
+	EditedAt   *time.Time          `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// NewComment creates a new visible comment entity. parentID is nil for a top-level comment.
+func NewComment(videoID primitive.ObjectID, parentID *primitive.ObjectID, authorID, authorName, body string) *Comment {
+	now := time.Now()
+	return &Comment{
+		ID:         primitive.NewObjectID(),
+		VideoID:    videoID,
+		ParentID:   parentID,
+		AuthorID:   authorID,
+		AuthorName: authorName,
+		Body:       body,
+		Status:     CommentStatusVisible,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// IsVisible reports whether the comment has been neither deleted nor removed
+func (c *Comment) IsVisible() bool {
+	return c.Status == CommentStatusVisible
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContentKey is an AES-128 key that encrypts HLS segments of a video.
+// The worker creates keys and stores them wrapped with the content key secret.
+type ContentKey struct {
+	ID         string             `json:"id" bson:"_id"`
+	VideoID    primitive.ObjectID `json:"video_id" bson:"video_id"`
+	Revision   int                `json:"revision" bson:"revision"`
+	Index      int                `json:"index" bson:"index"` // position in the rotation sequence
+	WrappedKey []byte             `json:"-" bson:"key"`
+	Nonce      []byte             `json:"-" bson:"nonce"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
@@ -19,6 +19,7 @@ const (
 	JobStatusProcessing JobStatus = "processing"
 	JobStatusCompleted  JobStatus = "completed"
 	JobStatusFailed     JobStatus = "failed"
+	JobStatusCancelled  JobStatus = "cancelled" // never picked up because its video was deleted
 )
 
 type Job struct {
@@ -27,6 +28,7 @@ type Job struct {
 	Type         JobType            `json:"type" bson:"type"`
 	Status       JobStatus          `json:"status" bson:"status"`
 	Progress     int                `json:"progress" bson:"progress"` // 0-100
+	Revision     int                `json:"revision" bson:"revision"` // source revision the job processes
 	ErrorMessage string             `json:"error_message,omitempty" bson:"error_message,omitempty"`
 	WorkerID     string             `json:"worker_id,omitempty" bson:"worker_id,omitempty"`
 	Payload      map[string]any     `json:"payload" bson:"payload"`
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Playlist is an ordered collection of videos curated by a user
+type Playlist struct {
+	ID          primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Owner       string               `json:"owner" bson:"owner"`
+	Title       string               `json:"title" bson:"title"`
+	Description string               `json:"description" bson:"description"`
+	Visibility  Visibility           `json:"visibility" bson:"visibility"`
+	VideoIDs    []primitive.ObjectID `json:"video_ids" bson:"video_ids"`
+	Version     int                  `json:"version" bson:"version"` // incremented by every change to VideoIDs
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+// NewPlaylist creates a new, empty playlist entity
+func NewPlaylist(owner, title, description string, visibility Visibility) *Playlist {
+	now := time.Now()
+	return &Playlist{
+		ID:          primitive.NewObjectID(),
+		Owner:       owner,
+		Title:       title,
+		Description: description,
+		Visibility:  visibility,
+		VideoIDs:    []primitive.ObjectID{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IndexOf returns the position of a video in the playlist, or -1 if it is not in it
+func (p *Playlist) IndexOf(videoID primitive.ObjectID) int {
+	for i, id := range p.VideoIDs {
+		if id == videoID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UploadLimits describes the upload quota applied to a user.
+// A zero value for any limit means "unlimited".
+type UploadLimits struct {
+	MaxFileSize     int64   `json:"max_file_size" bson:"max_file_size"`         // in bytes
+	MaxTotalStorage int64   `json:"max_total_storage" bson:"max_total_storage"` // in bytes
+	MaxVideosPerDay int     `json:"max_videos_per_day" bson:"max_videos_per_day"`
+	MaxDuration     float64 `json:"max_duration" bson:"max_duration"` // in seconds
+}
+
+// Plan groups upload limits under a named subscription tier
+type Plan struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string             `json:"name" bson:"name"`
+	Limits    UploadLimits       `json:"limits" bson:"limits"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// StorageUsage reports the storage consumed by a single uploader
+type StorageUsage struct {
+	OriginalsBytes  int64 `json:"originals_bytes" bson:"originals_bytes"`
+	RenditionsBytes int64 `json:"renditions_bytes" bson:"renditions_bytes"`
+	ThumbnailsBytes int64 `json:"thumbnails_bytes" bson:"thumbnails_bytes"`
+	CaptionsBytes   int64 `json:"captions_bytes" bson:"captions_bytes"`
+	VideoCount      int64 `json:"video_count" bson:"video_count"`
+}
+
+// NewPlan creates a new plan entity
+func NewPlan(name string, limits UploadLimits) *Plan {
+	now := time.Now()
+	return &Plan{
+		ID:        primitive.NewObjectID(),
+		Name:      name,
+		Limits:    limits,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// TotalBytes returns the combined size of all stored objects
+func (u *StorageUsage) TotalBytes() int64 {
+	return u.OriginalsBytes + u.RenditionsBytes + u.ThumbnailsBytes + u.CaptionsBytes
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReactionType is how a user rated a video
+type ReactionType string
+
+const (
+	ReactionLike    ReactionType = "like"
+	ReactionDislike ReactionType = "dislike"
+)
+
+// IsValid checks if the reaction is one of the known types
+func (t ReactionType) IsValid() bool {
+	return t == ReactionLike || t == ReactionDislike
+}
+
+// Reaction is one user's like or dislike of a video. Each user has at most one per video.
+type Reaction struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VideoID   primitive.ObjectID `json:"video_id" bson:"video_id"`
+	UserID    string             `json:"user_id" bson:"user_id"`
+	Type      ReactionType       `json:"type" bson:"type"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// ReactionCounts tallies the reactions to a video
+type ReactionCounts struct {
+	Likes    int64 `json:"likes" bson:"likes"`
+	Dislikes int64 `json:"dislikes" bson:"dislikes"`
+}
+
+// Add returns the counts with n reactions of type t added; a negative n removes them
+func (c ReactionCounts) Add(t ReactionType, n int64) ReactionCounts {
+	switch t {
+	case ReactionLike:
+		c.Likes += n
+	case ReactionDislike:
+		c.Dislikes += n
+	}
+	return c
+}
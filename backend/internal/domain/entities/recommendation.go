@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DailyReactions tallies the reactions given to a video on one UTC day
+type DailyReactions struct {
+	VideoID        primitive.ObjectID `json:"video_id" bson:"video_id"`
+	Day            time.Time          `json:"day" bson:"day"`
+	ReactionCounts `bson:",inline"`
+}
+
+// ScoredVideo is a video ranked by a recommendation score
+type ScoredVideo struct {
+	VideoID primitive.ObjectID
+	Score   float64
+}
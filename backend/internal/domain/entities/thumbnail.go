@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ThumbnailSize names one of the sizes every thumbnail is stored in. All sizes keep the aspect ratio
+// of the video or uploaded image.
+type ThumbnailSize string
+
+const (
+	ThumbnailSmall  ThumbnailSize = "small"  // 320 pixels wide
+	ThumbnailMedium ThumbnailSize = "medium" // 640 pixels wide
+	ThumbnailLarge  ThumbnailSize = "large"  // up to 1280 pixels wide; the object recorded for the thumbnail
+)
+
+// ThumbnailSizes lists the stored sizes, largest first
+var ThumbnailSizes = []ThumbnailSize{ThumbnailLarge, ThumbnailMedium, ThumbnailSmall}
+
+// IsValid checks if the size is one of the known values
+func (s ThumbnailSize) IsValid() bool {
+	return s == ThumbnailSmall || s == ThumbnailMedium || s == ThumbnailLarge
+}
+
+// Width returns the width of the size in pixels; images narrower than that are not enlarged
+func (s ThumbnailSize) Width() int {
+	switch s {
+	case ThumbnailSmall:
+		return 320
+	case ThumbnailMedium:
+		return 640
+	default:
+		return 1280
+	}
+}
+
+// CustomThumbnail is an image the uploader chose instead of the generated thumbnails. It survives
+// reprocessing and source replacement.
+type CustomThumbnail struct {
+	Object     string    `json:"object" bson:"object"` // large object in the thumbnails bucket
+	Size       int64     `json:"size" bson:"size"`     // in bytes, all sizes together
+	UploadedAt time.Time `json:"uploaded_at" bson:"uploaded_at"`
+}
+
+// ThumbnailObjectName returns the object of a thumbnail in a size. Thumbnails are recorded by their large
+// object, and the smaller sizes are stored next to it: "abc_thumb_1.jpg" has "abc_thumb_1_small.jpg".
+// Thumbnails generated before sizes existed only have the recorded object.
+func ThumbnailObjectName(object string, size ThumbnailSize) string {
+	if size == ThumbnailLarge || size == "" {
+		return object
+	}
+	ext := filepath.Ext(object)
+	return strings.TrimSuffix(object, ext) + "_" + string(size) + ext
+}
+
+// CustomThumbnailObjectName returns the object of a newly uploaded custom thumbnail. The name is new for
+// every upload so the previous image keeps serving until the video points at the new one.
+func (v *Video) CustomThumbnailObjectName(uploadedAt time.Time) string {
+	return v.ID.Hex() + "_custom_" + uploadedAt.Format("20060102150405") + ".jpg"
+}
+
+// HasThumbnail checks if an object is one of the video's generated thumbnails or its custom thumbnail
+func (v *Video) HasThumbnail(object string) bool {
+	if object == "" {
+		return false
+	}
+	if v.CustomThumbnail != nil && v.CustomThumbnail.Object == object {
+		return true
+	}
+	for _, thumbnail := range v.Thumbnails {
+		if thumbnail == object {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryThumbnailObject returns the thumbnail shown for the video: the one the uploader selected while it
+// still exists, otherwise the custom thumbnail, otherwise the first generated one. It returns an empty
+// string when the video has no thumbnails.
+func (v *Video) PrimaryThumbnailObject() string {
+	if v.HasThumbnail(v.PrimaryThumbnail) {
+		return v.PrimaryThumbnail
+	}
+	if v.CustomThumbnail != nil {
+		return v.CustomThumbnail.Object
+	}
+	if len(v.Thumbnails) > 0 {
+		return v.Thumbnails[0]
+	}
+	return ""
+}
@@ -4,14 +4,44 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
 )
 
+type UserRole string
+
+const (
+	RoleViewer    UserRole = "viewer"
+	RoleUploader  UserRole = "uploader"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
+)
+
+// roleRanks orders roles so that each role includes the permissions of the ones below it
+var roleRanks = map[UserRole]int{
+	RoleViewer:    1,
+	RoleUploader:  2,
+	RoleModerator: 3,
+	RoleAdmin:     4,
+}
+
+// IsValid checks if the role is one of the known roles
+func (r UserRole) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
 type User struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Username  string             `json:"username" bson:"username"`
-	Email     string             `json:"email" bson:"email"`
-	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Username      string             `json:"username" bson:"username"`
+	Email         string             `json:"email" bson:"email"`
+	PasswordHash  string             `json:"-" bson:"password_hash"`
+	Role          UserRole           `json:"role" bson:"role"`
+	Plan          string             `json:"plan,omitempty" bson:"plan,omitempty"`
+	Limits        *UploadLimits      `json:"limits,omitempty" bson:"limits,omitempty"`       // overrides plan limits
+	HistoryPaused bool               `json:"history_paused" bson:"history_paused,omitempty"` // stop recording watch history
+	HistorySince  time.Time          `json:"-" bson:"history_since,omitempty"`               // when the watch history was last cleared
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
 }
 
 // NewUser creates a new user entity
@@ -21,7 +51,53 @@ func NewUser(username, email string) *User {
 		ID:        primitive.NewObjectID(),
 		Username:  username,
 		Email:     email,
+		Role:      RoleUploader,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
+
+// SetPassword hashes and stores the user's password
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	u.PasswordHash = string(hash)
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// CheckPassword verifies a password against the stored hash
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// RecordsHistory reports whether progress of playback that started at startedAt belongs in the user's
+// watch history: recording is not paused and the history was not cleared since the playback started
+func (u *User) RecordsHistory(startedAt time.Time) bool {
+	return !u.HistoryPaused && !startedAt.Before(u.HistorySince)
+}
+
+// EffectiveRole returns the user's role. Accounts created before roles existed are uploaders.
+func (u *User) EffectiveRole() UserRole {
+	if u.Role == "" {
+		return RoleUploader
+	}
+	return u.Role
+}
+
+// HasRole checks if the user has the given role or a higher one
+func (u *User) HasRole(role UserRole) bool {
+	return roleRanks[u.EffectiveRole()] >= roleRanks[role]
+}
+
+// SetRole changes the user's role
+func (u *User) SetRole(role UserRole) {
+	u.Role = role
+	u.UpdatedAt = time.Now()
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserRecordsHistory(t *testing.T) {
+	cleared := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		user      User
+		startedAt time.Time
+		want      bool
+	}{
+		{name: "never cleared", user: User{}, startedAt: cleared, want: true},
+		{name: "paused", user: User{HistoryPaused: true}, startedAt: cleared, want: false},
+		{name: "started before the history was cleared", user: User{HistorySince: cleared}, startedAt: cleared.Add(-time.Minute), want: false},
+		{name: "started after the history was cleared", user: User{HistorySince: cleared}, startedAt: cleared.Add(time.Minute), want: true},
+		{name: "session from before start times were recorded", user: User{HistorySince: cleared}, startedAt: time.Time{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.RecordsHistory(tt.startedAt); got != tt.want {
+				t.Errorf("RecordsHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
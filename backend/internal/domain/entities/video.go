@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+	"path/filepath"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,25 +17,72 @@ const (
 	VideoStatusFailed     VideoStatus = "failed"
 )
 
+// Visibility controls who can find and watch a video
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"   // listed and watchable by anyone
+	VisibilityUnlisted Visibility = "unlisted" // watchable by anyone with the link, never listed
+	VisibilityPrivate  Visibility = "private"  // only the uploader and moderators
+)
+
+// IsValid checks if the visibility is one of the known values
+func (v Visibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityUnlisted || v == VisibilityPrivate
+}
+
 type VideoFormat struct {
 	Quality  string `json:"quality" bson:"quality"` // "480p", "720p", "1080p"
 	Filename string `json:"filename" bson:"filename"`
 	Size     int64  `json:"size" bson:"size"`
+	Playlist string `json:"playlist,omitempty" bson:"playlist,omitempty"` // HLS playlist object name relative to videos/hls/
+	HLSSize  int64  `json:"hls_size,omitempty" bson:"hls_size,omitempty"` // in bytes, playlist and segments
+}
+
+// SourceRevision records a previous original file of a video that was replaced
+type SourceRevision struct {
+	Revision         int       `json:"revision" bson:"revision"`
+	OriginalFilename string    `json:"original_filename" bson:"original_filename"`
+	ObjectName       string    `json:"object_name" bson:"object_name"`
+	Size             int64     `json:"size" bson:"size"`
+	ReplacedAt       time.Time `json:"replaced_at" bson:"replaced_at"`
 }
 
 type Video struct {
-	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title            string             `json:"title" bson:"title"`
-	Description      string             `json:"description" bson:"description"`
-	UploadedBy       string             `json:"uploaded_by" bson:"uploaded_by"`
-	OriginalFilename string             `json:"original_filename" bson:"original_filename"`
-	Duration         float64            `json:"duration" bson:"duration"` // in seconds
-	Size             int64              `json:"size" bson:"size"`         // in bytes
-	Status           VideoStatus        `json:"status" bson:"status"`
-	Formats          []VideoFormat      `json:"formats" bson:"formats"`
-	Thumbnails       []string           `json:"thumbnails" bson:"thumbnails"`
-	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at"`
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title             string             `json:"title" bson:"title"`
+	Description       string             `json:"description" bson:"description"`
+	Tags              []string           `json:"tags" bson:"tags"`
+	Category          string             `json:"category,omitempty" bson:"category,omitempty"` // slug of one of Categories
+	UploadedBy        string             `json:"uploaded_by" bson:"uploaded_by"`
+	ChannelID         primitive.ObjectID `json:"channel_id,omitempty" bson:"channel_id,omitempty"` // zero when published without a channel
+	Visibility        Visibility         `json:"visibility" bson:"visibility"`
+	PublishAt         *time.Time         `json:"publish_at,omitempty" bson:"publish_at,omitempty"` // scheduled release, hidden from others until then
+	Encrypted         bool               `json:"encrypted" bson:"encrypted"`                       // HLS segments are AES-128 encrypted and only streamed over HLS
+	OriginalFilename  string             `json:"original_filename" bson:"original_filename"`
+	Duration          float64            `json:"duration" bson:"duration"` // in seconds
+	Size              int64              `json:"size" bson:"size"`         // in bytes
+	Status            VideoStatus        `json:"status" bson:"status"`
+	Formats           []VideoFormat      `json:"formats" bson:"formats"`
+	Thumbnails        []string           `json:"thumbnails" bson:"thumbnails"`
+	PrimaryThumbnail  string             `json:"primary_thumbnail,omitempty" bson:"primary_thumbnail,omitempty"`
+	CustomThumbnail   *CustomThumbnail   `json:"custom_thumbnail,omitempty" bson:"custom_thumbnail,omitempty"`
+	ThumbnailsSize    int64              `json:"thumbnails_size" bson:"thumbnails_size"` // in bytes
+	SourceObject      string             `json:"source_object,omitempty" bson:"source_object,omitempty"`
+	Captions          []Caption          `json:"captions,omitempty" bson:"captions,omitempty"` // changed only through SetCaption and RemoveCaption
+	Chapters          []Chapter          `json:"chapters,omitempty" bson:"chapters,omitempty"` // set through the chapters API, see ResolveChapters; changed only through SetChapters
+	Revision          int                `json:"revision" bson:"revision"`
+	Revisions         []SourceRevision   `json:"revisions" bson:"revisions"`
+	PendingRevision   int                `json:"pending_revision,omitempty" bson:"pending_revision"` // renditions in progress for a replaced source
+	PendingFormats    []VideoFormat      `json:"pending_formats,omitempty" bson:"pending_formats"`
+	PendingThumbnails []string           `json:"pending_thumbnails,omitempty" bson:"pending_thumbnails"`
+	PendingError      string             `json:"pending_error,omitempty" bson:"pending_error"`
+	DeletedAt         *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"` // set while the video is in the trash
+	ViewCount         int64              `json:"view_count" bson:"view_count"`                     // maintained from view events with $inc, never written back
+	LikeCount         int64              `json:"like_count" bson:"like_count"`                     // maintained from reactions with $inc, never written back
+	DislikeCount      int64              `json:"dislike_count" bson:"dislike_count"`               // maintained from reactions with $inc, never written back
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`
 }
 
 // NewVideo creates a new video entity
@@ -44,11 +93,14 @@ func NewVideo(title, description, uploadedBy, originalFilename string, size int6
 		Title:            title,
 		Description:      description,
 		UploadedBy:       uploadedBy,
+		Visibility:       VisibilityPublic,
 		OriginalFilename: originalFilename,
 		Size:             size,
 		Status:           VideoStatusUploaded,
+		Tags:             []string{},
 		Formats:          []VideoFormat{},
 		Thumbnails:       []string{},
+		Revisions:        []SourceRevision{},
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
@@ -70,7 +122,7 @@ func (v *Video) AddFormat(quality, filename string, size int64) {
 	v.UpdatedAt = time.Now()
 }
 
-// AddThumbnail adds a new thumbnail
+// AddThumbnail adds a generated thumbnail candidate by its large object, see ThumbnailObjectName
 func (v *Video) AddThumbnail(filename string) {
 	v.Thumbnails = append(v.Thumbnails, filename)
 	v.UpdatedAt = time.Now()
@@ -81,6 +133,34 @@ func (v *Video) IsReady() bool {
 	return v.Status == VideoStatusReady
 }
 
+// IsTrashed checks if the video was deleted and awaits restore or purge
+func (v *Video) IsTrashed() bool {
+	return v.DeletedAt != nil
+}
+
+// Reactions returns the video's denormalized reaction counters
+func (v *Video) Reactions() ReactionCounts {
+	return ReactionCounts{Likes: v.LikeCount, Dislikes: v.DislikeCount}
+}
+
+// EffectiveVisibility returns the video's visibility. Videos created before visibility existed are public.
+func (v *Video) EffectiveVisibility() Visibility {
+	if v.Visibility == "" {
+		return VisibilityPublic
+	}
+	return v.Visibility
+}
+
+// IsPublished checks if the video's scheduled release time, if any, has passed
+func (v *Video) IsPublished(now time.Time) bool {
+	return v.PublishAt == nil || !v.PublishAt.After(now)
+}
+
+// IsWatchableByAnyone checks if the video can be watched without being its uploader
+func (v *Video) IsWatchableByAnyone(now time.Time) bool {
+	return v.EffectiveVisibility() != VisibilityPrivate && v.IsPublished(now)
+}
+
 // HasFormat checks if video has a specific format
 func (v *Video) HasFormat(quality string) bool {
 	for _, format := range v.Formats {
@@ -90,3 +170,92 @@ func (v *Video) HasFormat(quality string) bool {
 	}
 	return false
 }
+
+// GetFormat returns the rendition of the given quality, or nil if there is none
+func (v *Video) GetFormat(quality string) *VideoFormat {
+	for i := range v.Formats {
+		if v.Formats[i].Quality == quality {
+			return &v.Formats[i]
+		}
+	}
+	return nil
+}
+
+// OriginalObjectName returns the storage object name of the current source file
+func (v *Video) OriginalObjectName() string {
+	if v.SourceObject != "" {
+		return v.SourceObject
+	}
+	return "videos/original/" + v.ID.Hex() + filepath.Ext(v.OriginalFilename)
+}
+
+// CaptionObjectName returns the storage object name of the video's caption track in a language
+func (v *Video) CaptionObjectName(language string) string {
+	return "videos/captions/" + v.ID.Hex() + "/" + language + ".vtt"
+}
+
+// GetCaption returns the caption track in a language, or nil if there is none
+func (v *Video) GetCaption(language string) *Caption {
+	for i := range v.Captions {
+		if v.Captions[i].Language == language {
+			return &v.Captions[i]
+		}
+	}
+	return nil
+}
+
+// NextSourceObjectName returns the object name a replacement source file is stored under
+func (v *Video) NextSourceObjectName(filename string) string {
+	return fmt.Sprintf("videos/original/%s_r%d%s", v.ID.Hex(), v.Revision+1, filepath.Ext(filename))
+}
+
+// ReplaceSource keeps the current original as a revision and points the video at a new source.
+// Existing renditions stay in place until the new ones are promoted.
+func (v *Video) ReplaceSource(filename string, size int64) {
+	now := time.Now()
+	v.Revisions = append(v.Revisions, SourceRevision{
+		Revision:         v.Revision,
+		OriginalFilename: v.OriginalFilename,
+		ObjectName:       v.OriginalObjectName(),
+		Size:             v.Size,
+		ReplacedAt:       now,
+	})
+
+	v.SourceObject = v.NextSourceObjectName(filename)
+	v.Revision++
+	v.OriginalFilename = filename
+	v.Size = size
+	v.PendingRevision = v.Revision
+	v.PendingFormats = []VideoFormat{}
+	v.PendingThumbnails = []string{}
+	v.PendingError = ""
+	v.UpdatedAt = now
+}
+
+// StartReprocessing re-runs processing of the current source as a new pending revision. The source is not
+// replaced, but the new renditions get their own object names and the live ones keep serving until then.
+func (v *Video) StartReprocessing() {
+	v.SourceObject = v.OriginalObjectName()
+	v.Revision++
+	v.PendingRevision = v.Revision
+	v.PendingFormats = []VideoFormat{}
+	v.PendingThumbnails = []string{}
+	v.PendingError = ""
+	v.UpdatedAt = time.Now()
+}
+
+// PromotePendingRevision swaps the pending renditions in as the current ones
+func (v *Video) PromotePendingRevision() {
+	v.Formats = v.PendingFormats
+	v.Thumbnails = v.PendingThumbnails
+	v.PendingRevision = 0
+	v.PendingFormats = nil
+	v.PendingThumbnails = nil
+	v.Status = VideoStatusReady
+	v.UpdatedAt = time.Now()
+}
+
+// IsReprocessing checks if renditions for a replaced source are still being produced
+func (v *Video) IsReprocessing() bool {
+	return v.PendingRevision > 0 && v.PendingError == ""
+}
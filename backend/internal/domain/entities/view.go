@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RetentionBuckets is how many equal slices of a video's duration the audience retention curve has
+const RetentionBuckets = 20
+
+// ViewEventType is a playback event reported by players
+type ViewEventType string
+
+const (
+	ViewEventStart     ViewEventType = "start"     // playback began; opens a view session
+	ViewEventHeartbeat ViewEventType = "heartbeat" // playback is ongoing at the reported position
+	ViewEventEnd       ViewEventType = "end"       // playback stopped; closes the view session
+)
+
+// IsValid checks if the event is one of the known types
+func (t ViewEventType) IsValid() bool {
+	return t == ViewEventStart || t == ViewEventHeartbeat || t == ViewEventEnd
+}
+
+// ViewSession tracks one playback of a video between its start and end events
+type ViewSession struct {
+	ID          string             `json:"id"`
+	VideoID     primitive.ObjectID `json:"video_id"`
+	UserID      string             `json:"user_id,omitempty"` // records progress in this user's watch history; empty for anonymous or paused
+	Duration    float64            `json:"duration"`          // of the video, in seconds
+	Day         time.Time          `json:"day"`               // UTC day the playback started, which its statistics count towards
+	StartedAt   time.Time          `json:"started_at"`        // history cleared after this no longer records the playback
+	Position    float64            `json:"position"`          // last reported position in seconds
+	LastEventAt time.Time          `json:"last_event_at"`
+	Buckets     uint64             `json:"buckets"` // bit i is set once retention bucket i was watched
+}
+
+// RetentionBucket returns the retention bucket of a position, or -1 when the duration is unknown
+func RetentionBucket(position, duration float64) int {
+	if duration <= 0 {
+		return -1
+	}
+	bucket := int(position / duration * RetentionBuckets)
+	if bucket < 0 {
+		return 0
+	}
+	if bucket >= RetentionBuckets {
+		return RetentionBuckets - 1
+	}
+	return bucket
+}
+
+// ViewStatsDelta is what one view event adds to a video's daily statistics
+type ViewStatsDelta struct {
+	Views     int64   // deduplicated views
+	Plays     int64   // view sessions, including repeated ones
+	WatchTime float64 // in seconds
+	Buckets   []int   // retention buckets newly reached
+}
+
+// IsEmpty checks if the delta changes nothing
+func (d ViewStatsDelta) IsEmpty() bool {
+	return d.Views == 0 && d.Plays == 0 && d.WatchTime == 0 && len(d.Buckets) == 0
+}
+
+// DailyViewStats aggregates the playback of a video on one UTC day
+type DailyViewStats struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VideoID   primitive.ObjectID `json:"video_id" bson:"video_id"`
+	Day       time.Time          `json:"day" bson:"day"`
+	Views     int64              `json:"views" bson:"views"`
+	Plays     int64              `json:"plays" bson:"plays"`
+	WatchTime float64            `json:"watch_time" bson:"watch_time"` // in seconds
+	// Retention counts the plays that reached each bucket, keyed by bucket index
+	Retention map[string]int64 `json:"retention" bson:"retention"`
+}
+
+// RetentionAt returns how many plays reached a retention bucket
+func (s *DailyViewStats) RetentionAt(bucket int) int64 {
+	return s.Retention[strconv.Itoa(bucket)]
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// watchedThreshold is the share of a video after which it counts as watched to the end
+const watchedThreshold = 0.95
+
+// WatchProgress is how far a user got in a video, an entry of their watch history
+type WatchProgress struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    string             `json:"user_id" bson:"user_id"`
+	VideoID   primitive.ObjectID `json:"video_id" bson:"video_id"`
+	Position  float64            `json:"position" bson:"position"` // in seconds
+	Duration  float64            `json:"duration" bson:"duration"` // of the video, in seconds
+	Completed bool               `json:"completed" bson:"completed"`
+	WatchedAt time.Time          `json:"watched_at" bson:"watched_at"`
+}
+
+// NewWatchProgress records a user's position in a video
+func NewWatchProgress(userID string, videoID primitive.ObjectID, position, duration float64) *WatchProgress {
+	return &WatchProgress{
+		UserID:    userID,
+		VideoID:   videoID,
+		Position:  position,
+		Duration:  duration,
+		Completed: duration > 0 && position >= duration*watchedThreshold,
+		WatchedAt: time.Now(),
+	}
+}
+
+// ResumePosition returns where playback should continue: the saved position, or the start of a video watched to the end
+func (p *WatchProgress) ResumePosition() float64 {
+	if p.Completed {
+		return 0
+	}
+	return p.Position
+}
+
+// Fraction returns the watched share of the video, or 0 when its duration is unknown
+func (p *WatchProgress) Fraction() float64 {
+	if p.Duration <= 0 {
+		return 0
+	}
+	if p.Position >= p.Duration {
+		return 1
+	}
+	return p.Position / p.Duration
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error)
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time, interval time.Duration) error
+}
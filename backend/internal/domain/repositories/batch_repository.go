@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BatchRepository interface {
+	Create(ctx context.Context, batch *entities.Batch) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Batch, error)
+}
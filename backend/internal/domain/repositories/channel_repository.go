@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ChannelRepository interface {
+	// Create stores a new channel, failing with ErrDuplicate when its handle is taken
+	Create(ctx context.Context, channel *entities.Channel) error
+	// GetByID returns the channel, or nil if it does not exist
+	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Channel, error)
+	// GetByHandle returns the channel with the normalized handle, or nil if there is none
+	GetByHandle(ctx context.Context, handle string) (*entities.Channel, error)
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Channel, error)
+	// ListByOwner returns the channels of a user, oldest first
+	ListByOwner(ctx context.Context, owner string) ([]*entities.Channel, error)
+	UpdateProfile(ctx context.Context, id primitive.ObjectID, name, description string) error
+	// SetImage stores the object of a channel image and returns the object it replaced, empty if none
+	SetImage(ctx context.Context, id primitive.ObjectID, image entities.ChannelImage, objectName string) (string, error)
+	// IncrementSubscribers atomically adds delta to the channel's subscriber count
+	IncrementSubscribers(ctx context.Context, id primitive.ObjectID, delta int64) error
+}
+
+type SubscriptionRepository interface {
+	// Create stores a subscription and reports whether it is new; subscribing twice changes nothing
+	Create(ctx context.Context, subscription *entities.Subscription) (bool, error)
+	// Delete removes a subscription and reports whether it existed
+	Delete(ctx context.Context, userID string, channelID primitive.ObjectID) (bool, error)
+	Exists(ctx context.Context, userID string, channelID primitive.ObjectID) (bool, error)
+	// List returns up to limit subscriptions of the user after the cursor, newest first, and the cursor of the next page or nil on the last page
+	List(ctx context.Context, userID string, limit int, after *PageCursor) ([]*entities.Subscription, *PageCursor, error)
+	Count(ctx context.Context, userID string) (int64, error)
+	// ListChannelIDs returns the channels the user subscribed to, up to limit
+	ListChannelIDs(ctx context.Context, userID string, limit int) ([]primitive.ObjectID, error)
+}
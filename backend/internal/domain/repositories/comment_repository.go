@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommentSort orders comment listings
+type CommentSort string
+
+const (
+	CommentSortNewest CommentSort = "newest"
+	CommentSortOldest CommentSort = "oldest"
+	CommentSortTop    CommentSort = "top" // most replied to first, newest among equally replied to
+)
+
+// CommentFilter narrows comment listings and counts
+type CommentFilter struct {
+	// VideoID restricts results to comments on this video
+	VideoID *primitive.ObjectID
+	// ParentID restricts results to the replies of this comment
+	ParentID *primitive.ObjectID
+	// TopLevel restricts results to comments that start a thread
+	TopLevel bool
+	// Listed leaves out deleted and removed comments, unless replies still hang off them
+	Listed bool
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entities.Comment) error
+	// GetByID returns nil when the comment does not exist
+	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Comment, error)
+	UpdateBody(ctx context.Context, id primitive.ObjectID, body string) error
+	// SetStatus hides a visible comment and reports whether it was visible until now. Deleted comments
+	// lose their body; removed ones keep it for review.
+	SetStatus(ctx context.Context, id primitive.ObjectID, status entities.CommentStatus, removedBy string) (bool, error)
+	IncrementReplyCount(ctx context.Context, id primitive.ObjectID, delta int) error
+	// List returns up to limit comments after the cursor in the given order, and the cursor of the next page or nil on the last page
+	List(ctx context.Context, filter CommentFilter, sort CommentSort, limit int, after *PageCursor) ([]*entities.Comment, *PageCursor, error)
+	Count(ctx context.Context, filter CommentFilter) (int64, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
+}
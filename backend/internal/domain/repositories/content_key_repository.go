@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ContentKeyRepository interface {
+	GetByID(ctx context.Context, id string) (*entities.ContentKey, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
+}
@@ -0,0 +1,6 @@
+package repositories
+
+import "errors"
+
+// ErrDuplicate reports that a write conflicts with a unique index
+var ErrDuplicate = errors.New("duplicate key")
@@ -8,14 +8,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// JobFilter narrows job listings and counts
+type JobFilter struct {
+	// VideoID restricts results to the jobs of this video
+	VideoID *primitive.ObjectID
+	// Status restricts results to jobs in this state
+	Status entities.JobStatus
+}
+
 type JobRepository interface {
 	Create(ctx context.Context, job *entities.Job) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Job, error)
 	Update(ctx context.Context, job *entities.Job) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	GetByVideoID(ctx context.Context, videoID primitive.ObjectID) ([]*entities.Job, error)
+	GetByVideoIDs(ctx context.Context, videoIDs []primitive.ObjectID) ([]*entities.Job, error)
 	GetByStatus(ctx context.Context, status entities.JobStatus) ([]*entities.Job, error)
 	GetPendingJobs(ctx context.Context, limit int) ([]*entities.Job, error)
-	GetActiveJobs(ctx context.Context) ([]*entities.Job, error)
+	// List returns up to limit jobs after the cursor, newest first, and the cursor of the next page or nil on the last page
+	List(ctx context.Context, filter JobFilter, limit int, after *PageCursor) ([]*entities.Job, *PageCursor, error)
+	Count(ctx context.Context, filter JobFilter) (int64, error)
 	UpdateProgress(ctx context.Context, id primitive.ObjectID, progress int) error
+	CancelPendingByVideoID(ctx context.Context, videoID primitive.ObjectID) (int64, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
 }
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PageCursor marks the last item of a page. Listings continue after it in
+// created_at, _id descending order; searches order by Score first.
+type PageCursor struct {
+	Score     float64
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
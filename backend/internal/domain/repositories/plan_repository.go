@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+)
+
+type PlanRepository interface {
+	Create(ctx context.Context, plan *entities.Plan) error
+	GetByName(ctx context.Context, name string) (*entities.Plan, error)
+	Update(ctx context.Context, plan *entities.Plan) error
+	List(ctx context.Context) ([]*entities.Plan, error)
+}
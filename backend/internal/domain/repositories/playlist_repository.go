@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlaylistFilter narrows playlist listings and counts
+type PlaylistFilter struct {
+	// Owner restricts results to playlists of this user
+	Owner string
+	// PublicOnly restricts results to public playlists
+	PublicOnly bool
+}
+
+// PlaylistRepository stores playlists. Changes to the video list are single atomic updates,
+// so concurrent edits of one playlist never overwrite each other.
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *entities.Playlist) error
+	// GetByID returns nil when the playlist does not exist
+	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Playlist, error)
+	UpdateMetadata(ctx context.Context, id primitive.ObjectID, title, description string, visibility entities.Visibility) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// List returns up to limit playlists after the cursor, newest first, and the cursor of the next page or nil on the last page
+	List(ctx context.Context, filter PlaylistFilter, limit int, after *PageCursor) ([]*entities.Playlist, *PageCursor, error)
+	Count(ctx context.Context, filter PlaylistFilter) (int64, error)
+	// AddVideo inserts a video at position, or appends it when position is nil.
+	// It reports false when the playlist is gone, already contains the video or already holds maxVideos.
+	AddVideo(ctx context.Context, id, videoID primitive.ObjectID, position *int, maxVideos int) (bool, error)
+	// RemoveVideo reports false when the playlist is gone or does not contain the video
+	RemoveVideo(ctx context.Context, id, videoID primitive.ObjectID) (bool, error)
+	// ReorderVideos replaces the video list if the playlist is still at the given version and reports whether it was
+	ReorderVideos(ctx context.Context, id primitive.ObjectID, version int, videoIDs []primitive.ObjectID) (bool, error)
+	// RemoveVideoFromAll takes a video out of every playlist that contains it
+	RemoveVideoFromAll(ctx context.Context, videoID primitive.ObjectID) error
+}
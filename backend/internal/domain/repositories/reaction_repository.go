@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReactionRepository interface {
+	// Get returns the user's reaction to the video, or nil if there is none
+	Get(ctx context.Context, videoID primitive.ObjectID, userID string) (*entities.Reaction, error)
+	// Set records the user's reaction to the video and returns the reaction it replaced, empty if there was none
+	Set(ctx context.Context, videoID primitive.ObjectID, userID string, reactionType entities.ReactionType) (entities.ReactionType, error)
+	// Delete removes the user's reaction to the video and returns it, empty if there was none
+	Delete(ctx context.Context, videoID primitive.ObjectID, userID string) (entities.ReactionType, error)
+	// Count tallies the reactions to the video
+	Count(ctx context.Context, videoID primitive.ObjectID) (entities.ReactionCounts, error)
+	// CountDailySince tallies the reactions given or changed since the given time per video and UTC day
+	CountDailySince(ctx context.Context, since time.Time) ([]*entities.DailyReactions, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	Revoke(ctx context.Context, id primitive.ObjectID) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error
+}
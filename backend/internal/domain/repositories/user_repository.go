@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"youtube-backend/internal/domain/entities"
 
@@ -14,6 +15,9 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*entities.User, error)
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
+	SetHistoryPaused(ctx context.Context, id primitive.ObjectID, paused bool) error
+	// SetHistorySince records when the user's watch history was cleared
+	SetHistorySince(ctx context.Context, id primitive.ObjectID, since time.Time) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	List(ctx context.Context, limit, offset int) ([]*entities.User, error)
 }
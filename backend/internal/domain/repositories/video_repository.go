@@ -2,20 +2,85 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"youtube-backend/internal/domain/entities"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// VideoFilter narrows video listings, searches and counts
+type VideoFilter struct {
+	// ListedOnly restricts results to public videos whose release time has passed
+	ListedOnly bool
+	// Viewer, when set together with ListedOnly, also includes every video uploaded by this user
+	Viewer string
+	// UploadedBy restricts results to videos of this user
+	UploadedBy string
+	// Trashed selects videos in the trash instead of live ones
+	Trashed bool
+	// Text restricts results to videos matching a full-text search over title, tags and description
+	Text string
+	// Status restricts results to videos in this processing state
+	Status entities.VideoStatus
+	// MinDuration and MaxDuration bound the duration in seconds; zero means unbounded
+	MinDuration float64
+	MaxDuration float64
+	// UploadedAfter and UploadedBefore bound the upload time
+	UploadedAfter  *time.Time
+	UploadedBefore *time.Time
+	// Quality restricts results to videos with a rendition of this quality
+	Quality string
+	// Tag restricts results to videos carrying this tag
+	Tag string
+	// Category restricts results to videos filed under this category
+	Category string
+	// ChannelIDs restricts results to videos published under one of these channels
+	ChannelIDs []primitive.ObjectID
+}
+
 type VideoRepository interface {
 	Create(ctx context.Context, video *entities.Video) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Video, error)
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Video, error)
 	Update(ctx context.Context, video *entities.Video) error
+	UpdateMetadata(ctx context.Context, id primitive.ObjectID, title, description, category string, tags []string) error
+	// SetCaption adds the caption track, replacing the video's track in the same language
+	SetCaption(ctx context.Context, id primitive.ObjectID, caption entities.Caption) error
+	// RemoveCaption removes the video's caption track in the language and reports whether it existed
+	RemoveCaption(ctx context.Context, id primitive.ObjectID, language string) (bool, error)
+	// SetChapters replaces the chapters of a video; no chapters removes them
+	SetChapters(ctx context.Context, id primitive.ObjectID, chapters []entities.Chapter) error
+	// SetPrimaryThumbnail selects the thumbnail shown for a video; an empty object restores the default
+	SetPrimaryThumbnail(ctx context.Context, id primitive.ObjectID, object string) error
+	// SetCustomThumbnail replaces the custom thumbnail of a video and selects it, returning the one it
+	// replaced, if any. The thumbnail bytes of the video are adjusted by the difference.
+	SetCustomThumbnail(ctx context.Context, id primitive.ObjectID, thumbnail entities.CustomThumbnail) (*entities.CustomThumbnail, error)
+	// SetVisibility changes who can watch the video and when; a nil publishAt removes the scheduled release
+	SetVisibility(ctx context.Context, id primitive.ObjectID, visibility entities.Visibility, publishAt *time.Time) error
+	// SetChannel moves the video to another channel
+	SetChannel(ctx context.Context, id primitive.ObjectID, channelID primitive.ObjectID) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
-	List(ctx context.Context, limit, offset int) ([]*entities.Video, error)
+	SetDeletedAt(ctx context.Context, id primitive.ObjectID, deletedAt *time.Time) error
+	GetTrashedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.Video, error)
+	// List returns up to limit videos after the cursor, newest first, and the cursor of the next page or nil on the last page
+	List(ctx context.Context, filter VideoFilter, limit int, after *PageCursor) ([]*entities.Video, *PageCursor, error)
 	GetByStatus(ctx context.Context, status entities.VideoStatus) ([]*entities.Video, error)
-	GetByUploadedBy(ctx context.Context, uploadedBy string, limit, offset int) ([]*entities.Video, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*entities.Video, error)
-	Count(ctx context.Context) (int64, error)
+	GetByUploadedBy(ctx context.Context, uploadedBy string, limit int, after *PageCursor) ([]*entities.Video, *PageCursor, error)
+	// Search returns videos matching filter.Text, most relevant first
+	Search(ctx context.Context, filter VideoFilter, limit int, after *PageCursor) ([]*entities.Video, *PageCursor, error)
+	Count(ctx context.Context, filter VideoFilter) (int64, error)
+	CountByUploadedBySince(ctx context.Context, uploadedBy string, since time.Time) (int64, error)
+	GetStorageUsage(ctx context.Context, uploadedBy string) (*entities.StorageUsage, error)
+	// CountByCategory counts the videos matching filter in each category that has any
+	CountByCategory(ctx context.Context, filter VideoFilter) ([]entities.CategoryCount, error)
+	// IncrementViews atomically adds n to the video's view count
+	IncrementViews(ctx context.Context, id primitive.ObjectID, n int64) error
+	// IncrementReactions atomically adds delta to the video's reaction counters
+	IncrementReactions(ctx context.Context, id primitive.ObjectID, delta entities.ReactionCounts) error
+	// ResetReactions overwrites the reaction counters with counts, provided they still hold expected.
+	// It reports whether the counters were written.
+	ResetReactions(ctx context.Context, id primitive.ObjectID, expected, counts entities.ReactionCounts) (bool, error)
+	// CountByTag counts the videos matching filter for the most used tags starting with prefix, most used first
+	CountByTag(ctx context.Context, filter VideoFilter, prefix string, limit int) ([]entities.TagCount, error)
 }
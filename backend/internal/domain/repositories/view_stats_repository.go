@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ViewStatsRepository interface {
+	// Record atomically adds delta to the statistics of the video on the given day
+	Record(ctx context.Context, videoID primitive.ObjectID, day time.Time, delta entities.ViewStatsDelta) error
+	// GetDaily returns the statistics of the video for the days in [from, to], oldest first. Days without playback are missing.
+	GetDaily(ctx context.Context, videoID primitive.ObjectID, from, to time.Time) ([]*entities.DailyViewStats, error)
+	// ListViewsSince returns the daily view counts of every video with views on a day since from;
+	// the other statistics are left empty
+	ListViewsSince(ctx context.Context, from time.Time) ([]*entities.DailyViewStats, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WatchHistoryRepository interface {
+	// Save stores the user's progress in the video, replacing the previous one
+	Save(ctx context.Context, progress *entities.WatchProgress) error
+	// Get returns the user's progress in the video, or nil if they never watched it
+	Get(ctx context.Context, userID string, videoID primitive.ObjectID) (*entities.WatchProgress, error)
+	// List returns up to limit entries of the user's history after the cursor, most recently watched first,
+	// and the cursor of the next page or nil on the last page
+	List(ctx context.Context, userID string, limit int, after *PageCursor) ([]*entities.WatchProgress, *PageCursor, error)
+	Count(ctx context.Context, userID string) (int64, error)
+	Delete(ctx context.Context, userID string, videoID primitive.ObjectID) error
+	// DeleteByUserID clears the user's history and returns how many entries were removed
+	DeleteByUserID(ctx context.Context, userID string) (int64, error)
+	// ListRecentByUser returns, for every user who watched a video since the given time, the IDs of
+	// their up to perUser most recently watched videos
+	ListRecentByUser(ctx context.Context, since time.Time, perUser int) ([][]primitive.ObjectID, error)
+	DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// APIKeyPrefix marks API keys so they can be told apart from access tokens
+	APIKeyPrefix = "vhk_"
+
+	// lastUsedInterval limits how often last_used_at is written for busy keys
+	lastUsedInterval = time.Minute
+
+	maxAPIKeysPerUser = 20
+)
+
+type APIKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// IsAPIKey checks if a credential looks like an API key rather than an access token
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// CreateKey creates an API key for the user and returns it with the plaintext key,
+// which is not stored and cannot be retrieved again
+func (s *APIKeyService) CreateKey(ctx context.Context, userID primitive.ObjectID, name string, scopes []string) (*entities.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", ErrInvalidInput)
+	}
+	seen := make(map[string]bool)
+	var granted []string
+	for _, scope := range scopes {
+		if !entities.IsValidAPIKeyScope(scope) {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", ErrInvalidInput, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			granted = append(granted, scope)
+		}
+	}
+
+	existing, err := s.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	active := 0
+	for _, key := range existing {
+		if key.RevokedAt == nil {
+			active++
+		}
+	}
+	if active >= maxAPIKeysPerUser {
+		return nil, "", fmt.Errorf("%w: a user can have at most %d active API keys", ErrInvalidInput, maxAPIKeysPerUser)
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	plaintext := APIKeyPrefix + secret
+
+	key := entities.NewAPIKey(userID, name, plaintext[:len(APIKeyPrefix)+6], hashToken(plaintext), granted)
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plaintext, nil
+}
+
+// ListKeys lists the API keys of a user, including revoked ones
+func (s *APIKeyService) ListKeys(ctx context.Context, userID primitive.ObjectID) ([]*entities.APIKey, error) {
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// RevokeKey revokes one of the user's API keys
+func (s *APIKeyService) RevokeKey(ctx context.Context, userID, keyID primitive.ObjectID) error {
+	revoked, err := s.apiKeyRepo.Revoke(ctx, keyID, userID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate resolves an API key into the identity of its owner, restricted to the key's scopes
+func (s *APIKeyService) Authenticate(ctx context.Context, plaintext string) (*Identity, error) {
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashToken(plaintext))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, time.Now(), lastUsedInterval); err != nil {
+		return nil, err
+	}
+
+	return &Identity{User: user, APIKey: key}, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+const minPasswordLength = 8
+
+// maxPasswordLength is the most bytes bcrypt hashes; longer passwords are rejected rather than truncated
+const maxPasswordLength = 72
+
+// TokenManager issues and verifies short-lived access tokens
+type TokenManager interface {
+	IssueAccessToken(user *entities.User) (string, time.Time, error)
+	ParseAccessToken(token string) (primitive.ObjectID, error)
+}
+
+type AuthService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	tokenManager     TokenManager
+	refreshTokenTTL  time.Duration
+}
+
+// AuthTokens is the result of a successful login or refresh
+type AuthTokens struct {
+	User                  *entities.User
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// NewAuthService creates the auth service
+func NewAuthService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, tokenManager TokenManager, refreshTokenTTL time.Duration) *AuthService {
+	return &AuthService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		tokenManager:     tokenManager,
+		refreshTokenTTL:  refreshTokenTTL,
+	}
+}
+
+// Register creates a new user account
+func (s *AuthService) Register(ctx context.Context, username, email, password string) (*entities.User, error) {
+	username = strings.TrimSpace(username)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("%w: username must be 3-32 letters, digits, '.', '_' or '-'", ErrInvalidInput)
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("%w: invalid email address", ErrInvalidInput)
+	}
+	if len(password) < minPasswordLength {
+		return nil, fmt.Errorf("%w: password must be at least %d characters", ErrInvalidInput, minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password must be at most %d bytes", ErrInvalidInput, maxPasswordLength)
+	}
+
+	existing, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: username already exists", ErrUserExists)
+	}
+
+	existing, err = s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: email already exists", ErrUserExists)
+	}
+
+	user := entities.NewUser(username, email)
+	if err := user.SetPassword(password); err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: username or email already exists", ErrUserExists)
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Login verifies credentials and issues a new token pair. The login may be a username or an email.
+func (s *AuthService) Login(ctx context.Context, login, password string) (*AuthTokens, error) {
+	login = strings.TrimSpace(login)
+
+	var user *entities.User
+	var err error
+	if strings.Contains(login, "@") {
+		user, err = s.userRepo.GetByEmail(ctx, strings.ToLower(login))
+	} else {
+		user, err = s.userRepo.GetByUsername(ctx, login)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || !user.CheckPassword(password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Refresh exchanges a refresh token for a new token pair, rotating the refresh token.
+// Presenting an already rotated token revokes every session of the user.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	token, err := s.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidToken
+	}
+
+	if token.RevokedAt != nil {
+		if err := s.refreshTokenRepo.RevokeAllForUser(ctx, token.UserID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+	if !token.IsActive() {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.refreshTokenRepo.Revoke(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		// A concurrent refresh already rotated this token
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Logout revokes a refresh token
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	token, err := s.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+
+	_, err = s.refreshTokenRepo.Revoke(ctx, token.ID)
+	return err
+}
+
+// Authenticate resolves an access token into the identity of its user
+func (s *AuthService) Authenticate(ctx context.Context, accessToken string) (*Identity, error) {
+	userID, err := s.tokenManager.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &Identity{User: user}, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *AuthService) GetUser(ctx context.Context, id primitive.ObjectID) (*entities.User, error) {
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// SetRole changes the role of a user. Only admins can change roles.
+func (s *AuthService) SetRole(ctx context.Context, id primitive.ObjectID, role entities.UserRole) (*entities.User, error) {
+	identity, err := requireRole(ctx, entities.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidInput, role)
+	}
+	if id == identity.User.ID && role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w: admins cannot demote themselves", ErrInvalidInput)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SetRole(role)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// issueTokens creates an access token and a stored refresh token for the user
+func (s *AuthService) issueTokens(ctx context.Context, user *entities.User) (*AuthTokens, error) {
+	accessToken, accessExpiresAt, err := s.tokenManager.IssueAccessToken(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	stored := entities.NewRefreshToken(user.ID, hashToken(refreshToken), s.refreshTokenTTL)
+	if err := s.refreshTokenRepo.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		User:                  user,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// generateToken returns a random URL-safe token
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the SHA-256 hex digest under which a token is stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
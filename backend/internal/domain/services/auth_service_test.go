@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		email    string
+		password string
+	}{
+		{name: "short username", username: "al", email: "alice@example.com", password: "s3cret-pass"},
+		{name: "invalid email", username: "alice", email: "alice", password: "s3cret-pass"},
+		{name: "short password", username: "alice", email: "alice@example.com", password: "short"},
+		{name: "password longer than bcrypt accepts", username: "alice", email: "alice@example.com", password: strings.Repeat("a", 73)},
+		{name: "multi-byte password longer than bcrypt accepts", username: "alice", email: "alice@example.com", password: strings.Repeat("é", 37)},
+	}
+
+	// Input is validated before any repository is used
+	service := NewAuthService(nil, nil, nil, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Register(context.Background(), tt.username, tt.email, tt.password)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("Register() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BatchService struct {
+	batchRepo repositories.BatchRepository
+	videoRepo repositories.VideoRepository
+	jobRepo   repositories.JobRepository
+}
+
+// BatchProgress aggregates the processing state of all videos in a batch
+type BatchProgress struct {
+	Batch            *entities.Batch
+	Videos           []*entities.Video
+	VideoProgress    map[primitive.ObjectID]int
+	ReadyVideos      int
+	FailedVideos     int
+	ProcessingVideos int
+	TotalJobs        int
+	CompletedJobs    int
+	FailedJobs       int
+	Progress         int // 0-100, averaged over all jobs
+}
+
+func NewBatchService(batchRepo repositories.BatchRepository, videoRepo repositories.VideoRepository, jobRepo repositories.JobRepository) *BatchService {
+	return &BatchService{
+		batchRepo: batchRepo,
+		videoRepo: videoRepo,
+		jobRepo:   jobRepo,
+	}
+}
+
+// BatchStagingPrefix returns where a user stages objects in the videos bucket for batch uploads.
+// Batches only import objects under the caller's own prefix.
+func BatchStagingPrefix(userID string) string {
+	return "uploads/" + userID + "/"
+}
+
+// CheckStagedObject checks that an object named in a batch is staged under the caller's prefix
+func CheckStagedObject(ctx context.Context, object string) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	if path.Clean(object) != object || !strings.HasPrefix(object, BatchStagingPrefix(identity.UserID())) {
+		return fmt.Errorf("%w: object %q is not under %s", ErrForbidden, object, BatchStagingPrefix(identity.UserID()))
+	}
+	return nil
+}
+
+// CreateBatch records a batch for the given videos
+func (s *BatchService) CreateBatch(ctx context.Context, uploadedBy string, videoIDs []primitive.ObjectID) (*entities.Batch, error) {
+	if len(videoIDs) == 0 {
+		return nil, fmt.Errorf("batch must contain at least one video")
+	}
+
+	batch := entities.NewBatch(uploadedBy, videoIDs)
+	if err := s.batchRepo.Create(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// GetBatchProgress computes the aggregate progress across all jobs of a batch
+func (s *BatchService) GetBatchProgress(ctx context.Context, id primitive.ObjectID) (*BatchProgress, error) {
+	batch, err := s.batchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwnerOrModerator(ctx, batch.UploadedBy); err != nil {
+		return nil, err
+	}
+
+	found, err := s.videoRepo.GetByIDs(ctx, batch.VideoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch videos: %w", err)
+	}
+
+	// Report the videos in the order of the batch's items
+	byID := make(map[primitive.ObjectID]*entities.Video, len(found))
+	for _, video := range found {
+		byID[video.ID] = video
+	}
+	videos := make([]*entities.Video, 0, len(batch.VideoIDs))
+	for _, id := range batch.VideoIDs {
+		if video, ok := byID[id]; ok {
+			videos = append(videos, video)
+		}
+	}
+
+	jobs, err := s.jobRepo.GetByVideoIDs(ctx, batch.VideoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch jobs: %w", err)
+	}
+
+	progress := &BatchProgress{
+		Batch:         batch,
+		Videos:        videos,
+		VideoProgress: make(map[primitive.ObjectID]int),
+		TotalJobs:     len(jobs),
+	}
+
+	jobTotals := make(map[primitive.ObjectID]int)
+	jobCounts := make(map[primitive.ObjectID]int)
+	totalProgress := 0
+	for _, job := range jobs {
+		switch {
+		case job.IsCompleted():
+			progress.CompletedJobs++
+		case job.IsFailed():
+			progress.FailedJobs++
+		}
+		totalProgress += job.Progress
+		jobTotals[job.VideoID] += job.Progress
+		jobCounts[job.VideoID]++
+	}
+
+	if len(jobs) > 0 {
+		progress.Progress = totalProgress / len(jobs)
+	}
+
+	for _, video := range videos {
+		switch video.Status {
+		case entities.VideoStatusReady:
+			progress.ReadyVideos++
+		case entities.VideoStatusFailed:
+			progress.FailedVideos++
+		default:
+			progress.ProcessingVideos++
+		}
+		if jobCounts[video.ID] > 0 {
+			progress.VideoProgress[video.ID] = jobTotals[video.ID] / jobCounts[video.ID]
+		}
+	}
+
+	return progress, nil
+}
+
+// IsFinished reports whether every video in the batch reached a terminal state
+func (p *BatchProgress) IsFinished() bool {
+	return p.ProcessingVideos == 0
+}
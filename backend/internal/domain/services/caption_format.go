@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// cueTimingPattern matches a cue timing line of SRT (comma) or WebVTT (period) files. Hours are
+// optional in WebVTT; anything after the end time is WebVTT cue settings.
+var cueTimingPattern = regexp.MustCompile(`^((?:\d+:)?\d{2}:\d{2}[,.]\d{3})\s+-->\s+((?:\d+:)?\d{2}:\d{2}[,.]\d{3})(\s.*)?$`)
+
+// captionFile is a parsed and validated caption file
+type captionFile struct {
+	vtt  []byte // the file as WebVTT
+	cues int
+}
+
+// convertCaptions validates an SRT or WebVTT file and returns it as WebVTT. Files starting with the
+// WEBVTT signature are validated and normalized; everything else is parsed as SRT.
+func convertCaptions(data []byte) (*captionFile, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%w: caption file must be UTF-8 text", ErrInvalidInput)
+	}
+	text := strings.ReplaceAll(strings.ReplaceAll(string(data), "\r\n", "\n"), "\r", "\n")
+
+	if strings.HasPrefix(text, "WEBVTT") {
+		return validateWebVTT(text)
+	}
+	return convertSRT(text)
+}
+
+// convertSRT parses numbered SRT cues and writes them as WebVTT
+func convertSRT(text string) (*captionFile, error) {
+	var out strings.Builder
+	out.WriteString("WEBVTT\n")
+
+	cues := 0
+	for _, block := range captionBlocks(text) {
+		lines := block.lines
+		// The cue number is optional in practice, so only skip it when a timing line follows
+		if len(lines) > 1 && isCueNumber(lines[0]) {
+			lines = lines[1:]
+		}
+
+		start, end, err := parseCueTiming(lines[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid caption file: line %d: %v", ErrInvalidInput, block.line+len(block.lines)-len(lines), err)
+		}
+		if len(lines) < 2 {
+			return nil, fmt.Errorf("%w: invalid caption file: line %d: cue has no text", ErrInvalidInput, block.line)
+		}
+
+		fmt.Fprintf(&out, "\n%s --> %s\n", formatCueTime(start), formatCueTime(end))
+		for _, line := range lines[1:] {
+			// "-->" would end the cue payload in WebVTT
+			out.WriteString(strings.ReplaceAll(line, "-->", "->"))
+			out.WriteByte('\n')
+		}
+		cues++
+	}
+
+	if cues == 0 {
+		return nil, fmt.Errorf("%w: caption file has no cues", ErrInvalidInput)
+	}
+	return &captionFile{vtt: []byte(out.String()), cues: cues}, nil
+}
+
+// validateWebVTT checks the header and every cue timing of a WebVTT file
+func validateWebVTT(text string) (*captionFile, error) {
+	blocks := captionBlocks(text)
+	header := blocks[0].lines[0]
+	if header != "WEBVTT" && !strings.HasPrefix(header, "WEBVTT ") && !strings.HasPrefix(header, "WEBVTT\t") {
+		return nil, fmt.Errorf("%w: invalid caption file: line 1: malformed WEBVTT header", ErrInvalidInput)
+	}
+
+	cues := 0
+	for _, block := range blocks[1:] {
+		first := block.lines[0]
+		if first == "NOTE" || strings.HasPrefix(first, "NOTE ") || strings.HasPrefix(first, "NOTE\t") || first == "STYLE" || first == "REGION" {
+			continue
+		}
+
+		// A cue starts with an optional identifier, then its timing line
+		timing := 0
+		if !strings.Contains(first, "-->") {
+			timing = 1
+		}
+		if timing >= len(block.lines) {
+			return nil, fmt.Errorf("%w: invalid caption file: line %d: cue has no timing", ErrInvalidInput, block.line)
+		}
+		if _, _, err := parseCueTiming(block.lines[timing]); err != nil {
+			return nil, fmt.Errorf("%w: invalid caption file: line %d: %v", ErrInvalidInput, block.line+timing, err)
+		}
+		cues++
+	}
+
+	if cues == 0 {
+		return nil, fmt.Errorf("%w: caption file has no cues", ErrInvalidInput)
+	}
+
+	vtt := strings.TrimRight(text, "\n") + "\n"
+	return &captionFile{vtt: []byte(vtt), cues: cues}, nil
+}
+
+// captionBlock is a run of non-blank lines and the line number it starts at
+type captionBlock struct {
+	line  int
+	lines []string
+}
+
+// captionBlocks splits a caption file into blocks separated by blank lines
+func captionBlocks(text string) []captionBlock {
+	var blocks []captionBlock
+	var current *captionBlock
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, captionBlock{line: i + 1})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, line)
+	}
+	return blocks
+}
+
+func isCueNumber(line string) bool {
+	_, err := strconv.Atoi(strings.TrimSpace(line))
+	return err == nil
+}
+
+// parseCueTiming parses a timing line into its start and end offsets
+func parseCueTiming(line string) (time.Duration, time.Duration, error) {
+	match := cueTimingPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return 0, 0, fmt.Errorf("expected a cue timing such as 00:00:01,000 --> 00:00:04,000")
+	}
+
+	start, err := parseCueTime(match[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseCueTime(match[2])
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("cue ends before it starts")
+	}
+
+	return start, end, nil
+}
+
+// parseCueTime parses [hh:]mm:ss,mmm or [hh:]mm:ss.mmm
+func parseCueTime(value string) (time.Duration, error) {
+	value = strings.Replace(value, ",", ".", 1)
+	parts := strings.Split(value, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+
+	hours, _ := strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	secondParts := strings.SplitN(parts[2], ".", 2)
+	seconds, _ := strconv.Atoi(secondParts[0])
+	millis, _ := strconv.Atoi(secondParts[1])
+	if minutes > 59 || seconds > 59 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, nil
+}
+
+// formatCueTime writes an offset as a WebVTT timestamp, hh:mm:ss.mmm
+func formatCueTime(offset time.Duration) string {
+	millis := offset.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", millis/3_600_000, millis/60_000%60, millis/1000%60, millis%1000)
+}
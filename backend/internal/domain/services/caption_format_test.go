@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCueTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "00:00:01,000", want: time.Second},
+		{value: "00:00:01.500", want: 1500 * time.Millisecond},
+		{value: "01:02:03,004", want: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond},
+		{value: "02:03.004", want: 2*time.Minute + 3*time.Second + 4*time.Millisecond},
+		{value: "123:00:00.000", want: 123 * time.Hour},
+		{value: "00:60:00,000", wantErr: true},
+		{value: "00:00:60.000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCueTime(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseCueTime(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCueTime(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseCueTime(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertSRT(t *testing.T) {
+	tests := []struct {
+		name     string
+		srt      string
+		want     string
+		wantCues int
+		wantErr  bool
+	}{
+		{
+			name:     "numbered cues",
+			srt:      "1\n00:00:01,000 --> 00:00:04,000\nHello\n\n2\n00:00:05,500 --> 00:00:07,250\nTwo\nlines\n",
+			want:     "WEBVTT\n\n00:00:01.000 --> 00:00:04.000\nHello\n\n00:00:05.500 --> 00:00:07.250\nTwo\nlines\n",
+			wantCues: 2,
+		},
+		{
+			name:     "cue numbers are optional",
+			srt:      "00:00:01,000 --> 00:00:02,000\nHello\n",
+			want:     "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+			wantCues: 1,
+		},
+		{
+			name:     "a number as cue text is kept",
+			srt:      "1\n00:00:01,000 --> 00:00:02,000\n42\n",
+			want:     "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n42\n",
+			wantCues: 1,
+		},
+		{
+			name:     "arrows in text are escaped",
+			srt:      "1\n00:00:01,000 --> 00:00:02,000\nA --> B\n",
+			want:     "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nA -> B\n",
+			wantCues: 1,
+		},
+		{
+			name:     "extra blank lines and trailing spaces",
+			srt:      "\n\n1  \n00:00:01,000 --> 00:00:02,000  \nHello  \n\n\n\n",
+			want:     "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+			wantCues: 1,
+		},
+		{name: "empty", srt: "", wantErr: true},
+		{name: "missing timing", srt: "1\nHello\n", wantErr: true},
+		{name: "cue without text", srt: "00:00:01,000 --> 00:00:02,000\n", wantErr: true},
+		{name: "ends before it starts", srt: "1\n00:00:04,000 --> 00:00:01,000\nHello\n", wantErr: true},
+		{name: "malformed time", srt: "1\n00:00:1,000 --> 00:00:02,000\nHello\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertSRT(tt.srt)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("convertSRT() error = %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertSRT() error = %v", err)
+			}
+			if string(got.vtt) != tt.want {
+				t.Errorf("convertSRT() =\n%s\nwant\n%s", got.vtt, tt.want)
+			}
+			if got.cues != tt.wantCues {
+				t.Errorf("convertSRT() cues = %d, want %d", got.cues, tt.wantCues)
+			}
+		})
+	}
+}
+
+func TestValidateWebVTT(t *testing.T) {
+	tests := []struct {
+		name     string
+		vtt      string
+		want     string
+		wantCues int
+		wantErr  bool
+	}{
+		{
+			name:     "cues with and without identifiers",
+			vtt:      "WEBVTT\n\nintro\n00:01.000 --> 00:04.000\nHello\n\n00:00:05.000 --> 00:00:07.000 align:start\nWorld\n",
+			want:     "WEBVTT\n\nintro\n00:01.000 --> 00:04.000\nHello\n\n00:00:05.000 --> 00:00:07.000 align:start\nWorld\n",
+			wantCues: 2,
+		},
+		{
+			name:     "header text, notes, styles and regions are not cues",
+			vtt:      "WEBVTT - English\n\nNOTE written by hand\n\nSTYLE\n::cue { color: white }\n\nREGION\nid:fred\n\n00:01.000 --> 00:02.000\nHi\n",
+			want:     "WEBVTT - English\n\nNOTE written by hand\n\nSTYLE\n::cue { color: white }\n\nREGION\nid:fred\n\n00:01.000 --> 00:02.000\nHi\n",
+			wantCues: 1,
+		},
+		{
+			name:     "trailing blank lines are trimmed",
+			vtt:      "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n\n\n",
+			want:     "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n",
+			wantCues: 1,
+		},
+		{name: "malformed header", vtt: "WEBVTTX\n\n00:01.000 --> 00:02.000\nHi\n", wantErr: true},
+		{name: "no cues", vtt: "WEBVTT\n\nNOTE nothing here\n", wantErr: true},
+		{name: "identifier without timing", vtt: "WEBVTT\n\nintro\n", wantErr: true},
+		{name: "malformed timing", vtt: "WEBVTT\n\n00:01 --> 00:02\nHi\n", wantErr: true},
+		{name: "ends before it starts", vtt: "WEBVTT\n\n00:02.000 --> 00:01.000\nHi\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateWebVTT(tt.vtt)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("validateWebVTT() error = %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateWebVTT() error = %v", err)
+			}
+			if string(got.vtt) != tt.want {
+				t.Errorf("validateWebVTT() =\n%s\nwant\n%s", got.vtt, tt.want)
+			}
+			if got.cues != tt.wantCues {
+				t.Errorf("validateWebVTT() cues = %d, want %d", got.cues, tt.wantCues)
+			}
+		})
+	}
+}
+
+func TestConvertCaptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "SRT with BOM and CRLF", data: "\xef\xbb\xbf1\r\n00:00:01,000 --> 00:00:02,000\r\nHi\r\n", want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHi\n"},
+		{name: "WebVTT with CR line ends", data: "WEBVTT\r\r00:01.000 --> 00:02.000\rHi\r", want: "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n"},
+		{name: "not UTF-8", data: "1\n00:00:01,000 --> 00:00:02,000\n\xff\xfe\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertCaptions([]byte(tt.data))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("convertCaptions() error = %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertCaptions() error = %v", err)
+			}
+			if string(got.vtt) != tt.want {
+				t.Errorf("convertCaptions() =\n%s\nwant\n%s", got.vtt, tt.want)
+			}
+		})
+	}
+}
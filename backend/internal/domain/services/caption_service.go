@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MaxCaptionFileSize bounds uploaded caption files
+	MaxCaptionFileSize = 2 << 20 // 2MB
+	// maxCaptionTracks bounds how many languages a video can have captions in
+	maxCaptionTracks = 50
+)
+
+// CaptionStore stores caption files in the videos bucket
+type CaptionStore interface {
+	UploadFile(ctx context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
+	DeleteFile(ctx context.Context, objectName string) error
+}
+
+type CaptionService struct {
+	videoRepo    repositories.VideoRepository
+	captionStore CaptionStore
+	quotaService *QuotaService
+}
+
+// UploadCaptionInput holds a caption file and the track it becomes
+type UploadCaptionInput struct {
+	Language string // BCP 47 tag; a track in the same language is replaced
+	Label    string // defaults to the language tag
+	Data     []byte // SRT or WebVTT
+}
+
+func NewCaptionService(videoRepo repositories.VideoRepository, captionStore CaptionStore, quotaService *QuotaService) *CaptionService {
+	return &CaptionService{
+		videoRepo:    videoRepo,
+		captionStore: captionStore,
+		quotaService: quotaService,
+	}
+}
+
+// UploadCaption validates a caption file, converts SRT to WebVTT and stores it as the video's track in the language.
+// Caption files count towards the uploader's storage quota.
+func (s *CaptionService) UploadCaption(ctx context.Context, videoID primitive.ObjectID, input UploadCaptionInput) (*entities.Caption, error) {
+	video, err := loadVideoForChange(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	language := entities.NormalizeLanguage(input.Language)
+	if language == "" {
+		return nil, fmt.Errorf("%w: language must be a BCP 47 tag such as en or pt-BR", ErrInvalidInput)
+	}
+	if len(input.Data) > MaxCaptionFileSize {
+		return nil, fmt.Errorf("%w: caption file must be at most %d bytes", ErrInvalidInput, MaxCaptionFileSize)
+	}
+	if video.GetCaption(language) == nil && len(video.Captions) >= maxCaptionTracks {
+		return nil, fmt.Errorf("%w: a video can have at most %d caption tracks", ErrInvalidInput, maxCaptionTracks)
+	}
+
+	label := strings.TrimSpace(input.Label)
+	if err := entities.ValidateCaptionLabel(label); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if label == "" {
+		label = language
+	}
+
+	file, err := convertCaptions(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	caption := entities.Caption{
+		Language:   language,
+		Label:      label,
+		Object:     video.CaptionObjectName(language),
+		Size:       int64(len(file.vtt)),
+		Cues:       file.cues,
+		UploadedAt: time.Now(),
+	}
+
+	// A replaced track frees its own bytes
+	added := caption.Size
+	if existing := video.GetCaption(language); existing != nil {
+		added -= existing.Size
+	}
+	if err := s.quotaService.CheckStorage(ctx, video.UploadedBy, added); err != nil {
+		return nil, err
+	}
+
+	if err := s.captionStore.UploadFile(ctx, caption.Object, bytes.NewReader(file.vtt), caption.Size, "text/vtt"); err != nil {
+		return nil, fmt.Errorf("failed to store caption file: %w", err)
+	}
+	if err := s.videoRepo.SetCaption(ctx, video.ID, caption); err != nil {
+		return nil, err
+	}
+
+	return &caption, nil
+}
+
+// GetCaption returns a video the caller may watch and its caption track in the language
+func (s *CaptionService) GetCaption(ctx context.Context, videoID primitive.ObjectID, language string) (*entities.Video, *entities.Caption, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, nil, ErrVideoNotFound
+	}
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, nil, err
+	}
+
+	caption := video.GetCaption(entities.NormalizeLanguage(language))
+	if caption == nil {
+		return nil, nil, ErrCaptionNotFound
+	}
+	return video, caption, nil
+}
+
+// DeleteCaption removes the video's caption track in the language
+func (s *CaptionService) DeleteCaption(ctx context.Context, videoID primitive.ObjectID, language string) error {
+	video, err := loadVideoForChange(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return err
+	}
+
+	caption := video.GetCaption(entities.NormalizeLanguage(language))
+	if caption == nil {
+		return ErrCaptionNotFound
+	}
+
+	removed, err := s.videoRepo.RemoveCaption(ctx, video.ID, caption.Language)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrCaptionNotFound
+	}
+
+	if err := s.captionStore.DeleteFile(ctx, caption.Object); err != nil {
+		return fmt.Errorf("failed to delete caption file: %w", err)
+	}
+	return nil
+}
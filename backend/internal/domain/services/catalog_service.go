@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+)
+
+// CategorySummary is a category of the taxonomy with the number of videos the caller can discover in it
+type CategorySummary struct {
+	entities.Category
+	VideoCount int64
+}
+
+// CatalogService describes how the catalog is organized into categories and tags
+type CatalogService struct {
+	videoRepo repositories.VideoRepository
+}
+
+func NewCatalogService(videoRepo repositories.VideoRepository) *CatalogService {
+	return &CatalogService{
+		videoRepo: videoRepo,
+	}
+}
+
+// ListCategories returns every category of the taxonomy, including empty ones, with video counts
+func (s *CatalogService) ListCategories(ctx context.Context) ([]CategorySummary, error) {
+	counts, err := s.videoRepo.CountByCategory(ctx, listingFilter(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	countBySlug := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countBySlug[count.Category] = count.Count
+	}
+
+	summaries := make([]CategorySummary, len(entities.Categories))
+	for i, category := range entities.Categories {
+		summaries[i] = CategorySummary{Category: category, VideoCount: countBySlug[category.Slug]}
+	}
+	return summaries, nil
+}
+
+// ListTags returns the most used tags among the videos the caller can discover, optionally
+// only those starting with prefix, so clients can suggest tags while the user types
+func (s *CatalogService) ListTags(ctx context.Context, prefix string, limit int) ([]entities.TagCount, error) {
+	return s.videoRepo.CountByTag(ctx, listingFilter(ctx), normalizeTag(prefix), limit)
+}
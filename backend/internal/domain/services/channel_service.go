@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxChannelsPerUser  = 10
+	maxChannelImageSize = 5 << 20 // 5MB
+	// maxFeedChannels bounds how many subscribed channels the subscription feed draws from
+	maxFeedChannels = 500
+)
+
+// channelImageTypes maps the accepted channel image content types to their file extensions
+var channelImageTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// ImageStore stores channel images in the thumbnails bucket
+type ImageStore interface {
+	UploadThumbnail(ctx context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
+	DeleteThumbnail(ctx context.Context, objectName string) error
+}
+
+type ChannelService struct {
+	channelRepo      repositories.ChannelRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	videoRepo        repositories.VideoRepository
+	imageStore       ImageStore
+}
+
+// SubscriptionPage is one page of a user's subscriptions
+type SubscriptionPage struct {
+	Subscriptions []*entities.Subscription
+	// Channels holds the subscribed channels by ID; channels deleted since are missing
+	Channels map[primitive.ObjectID]*entities.Channel
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every subscription of the user, across all pages
+	Total int64
+}
+
+// CreateChannelInput holds the profile of a new channel
+type CreateChannelInput struct {
+	Handle      string
+	Name        string
+	Description string
+}
+
+// UpdateChannelInput holds the profile fields to change; nil fields are left as they are
+type UpdateChannelInput struct {
+	Name        *string
+	Description *string
+}
+
+func NewChannelService(channelRepo repositories.ChannelRepository, subscriptionRepo repositories.SubscriptionRepository, videoRepo repositories.VideoRepository, imageStore ImageStore) *ChannelService {
+	return &ChannelService{
+		channelRepo:      channelRepo,
+		subscriptionRepo: subscriptionRepo,
+		videoRepo:        videoRepo,
+		imageStore:       imageStore,
+	}
+}
+
+// CreateChannel creates a channel owned by the caller, who must be allowed to upload
+func (s *ChannelService) CreateChannel(ctx context.Context, input CreateChannelInput) (*entities.Channel, error) {
+	identity, err := authorizeUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := entities.NormalizeHandle(input.Handle)
+	if !entities.IsValidHandle(handle) {
+		return nil, fmt.Errorf("%w: handle must be 3 to 30 letters, digits, '.', '_' or '-'", ErrInvalidInput)
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	existing, err := s.channelRepo.ListByOwner(ctx, identity.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxChannelsPerUser {
+		return nil, fmt.Errorf("%w: a user can own at most %d channels", ErrInvalidInput, maxChannelsPerUser)
+	}
+
+	channel := entities.NewChannel(identity.UserID(), handle, name, input.Description)
+	if err := s.channelRepo.Create(ctx, channel); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrHandleTaken
+		}
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// GetChannel returns a channel by ID or by its handle prefixed with '@'
+func (s *ChannelService) GetChannel(ctx context.Context, ref string) (*entities.Channel, error) {
+	var channel *entities.Channel
+	var err error
+	if strings.HasPrefix(ref, "@") {
+		channel, err = s.channelRepo.GetByHandle(ctx, entities.NormalizeHandle(ref))
+	} else {
+		id, parseErr := primitive.ObjectIDFromHex(ref)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: invalid channel ID", ErrInvalidInput)
+		}
+		channel, err = s.channelRepo.GetByID(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil {
+		return nil, ErrChannelNotFound
+	}
+
+	return channel, nil
+}
+
+// ListChannels returns the channels of a user, oldest first
+func (s *ChannelService) ListChannels(ctx context.Context, owner string) ([]*entities.Channel, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("%w: owner is required", ErrInvalidInput)
+	}
+	return s.channelRepo.ListByOwner(ctx, owner)
+}
+
+// UpdateChannel changes the name or description of a channel
+func (s *ChannelService) UpdateChannel(ctx context.Context, ref string, input UpdateChannelInput) (*entities.Channel, error) {
+	channel, err := s.getChannelForChange(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if strings.TrimSpace(*input.Name) == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidInput)
+		}
+		channel.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		channel.Description = *input.Description
+	}
+
+	if err := s.channelRepo.UpdateProfile(ctx, channel.ID, channel.Name, channel.Description); err != nil {
+		return nil, err
+	}
+	channel.UpdatedAt = time.Now()
+
+	return channel, nil
+}
+
+// SetImage uploads a new avatar or banner for a channel and removes the one it replaces
+func (s *ChannelService) SetImage(ctx context.Context, ref string, image entities.ChannelImage, reader io.Reader, size int64, contentType string) (*entities.Channel, error) {
+	if !image.IsValid() {
+		return nil, fmt.Errorf("%w: unknown channel image %q", ErrInvalidInput, image)
+	}
+	ext, ok := channelImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: image must be JPEG, PNG or WebP", ErrInvalidInput)
+	}
+	if size <= 0 || size > maxChannelImageSize {
+		return nil, fmt.Errorf("%w: image must be at most %d bytes", ErrInvalidInput, maxChannelImageSize)
+	}
+
+	channel, err := s.getChannelForChange(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// A fresh object name per upload keeps the old image intact until the channel points at the new one
+	objectName := fmt.Sprintf("channels/%s/%s-%d.%s", channel.ID.Hex(), image, time.Now().Unix(), ext)
+	if err := s.imageStore.UploadThumbnail(ctx, objectName, reader, size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store channel image: %w", err)
+	}
+
+	previous, err := s.channelRepo.SetImage(ctx, channel.ID, image, objectName)
+	if err != nil {
+		_ = s.imageStore.DeleteThumbnail(ctx, objectName)
+		return nil, err
+	}
+	if previous != "" && previous != objectName {
+		// A leftover object only wastes space, so a failed delete does not fail the upload
+		_ = s.imageStore.DeleteThumbnail(ctx, previous)
+	}
+
+	if image == entities.ChannelBanner {
+		channel.BannerObject = objectName
+	} else {
+		channel.AvatarObject = objectName
+	}
+	channel.UpdatedAt = time.Now()
+
+	return channel, nil
+}
+
+// Subscribe subscribes the caller to a channel. Subscribing again changes nothing.
+func (s *ChannelService) Subscribe(ctx context.Context, ref string) (*entities.Channel, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := s.GetChannel(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if channel.Owner == identity.UserID() {
+		return nil, fmt.Errorf("%w: cannot subscribe to your own channel", ErrInvalidInput)
+	}
+
+	created, err := s.subscriptionRepo.Create(ctx, entities.NewSubscription(identity.UserID(), channel.ID))
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		if err := s.channelRepo.IncrementSubscribers(ctx, channel.ID, 1); err != nil {
+			return nil, err
+		}
+		channel.SubscriberCount++
+	}
+
+	return channel, nil
+}
+
+// Unsubscribe removes the caller's subscription to a channel, if any
+func (s *ChannelService) Unsubscribe(ctx context.Context, ref string) (*entities.Channel, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := s.GetChannel(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.subscriptionRepo.Delete(ctx, identity.UserID(), channel.ID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		if err := s.channelRepo.IncrementSubscribers(ctx, channel.ID, -1); err != nil {
+			return nil, err
+		}
+		channel.SubscriberCount--
+	}
+
+	return channel, nil
+}
+
+// Subscribed reports whether the caller is subscribed to the channel; anonymous callers never are
+func (s *ChannelService) Subscribed(ctx context.Context, channelID primitive.ObjectID) (bool, error) {
+	identity := IdentityFromContext(ctx)
+	if identity == nil {
+		return false, nil
+	}
+	return s.subscriptionRepo.Exists(ctx, identity.UserID(), channelID)
+}
+
+// ListSubscriptions returns a page of the caller's subscriptions, newest first
+func (s *ChannelService) ListSubscriptions(ctx context.Context, limit int, cursor string) (*SubscriptionPage, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, next, err := s.subscriptionRepo.List(ctx, identity.UserID(), limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.subscriptionRepo.Count(ctx, identity.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(subscriptions))
+	for i, subscription := range subscriptions {
+		ids[i] = subscription.ChannelID
+	}
+	channels, err := s.channelRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*entities.Channel, len(channels))
+	for _, channel := range channels {
+		byID[channel.ID] = channel
+	}
+
+	return &SubscriptionPage{Subscriptions: subscriptions, Channels: byID, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// Feed returns a page of the latest listed videos from the channels the caller subscribed to
+func (s *ChannelService) Feed(ctx context.Context, limit int, cursor string) (*VideoPage, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs, err := s.subscriptionRepo.ListChannelIDs(ctx, identity.UserID(), maxFeedChannels)
+	if err != nil {
+		return nil, err
+	}
+	if len(channelIDs) == 0 {
+		return &VideoPage{Videos: []*entities.Video{}}, nil
+	}
+
+	filter := repositories.VideoFilter{ListedOnly: true, ChannelIDs: channelIDs}
+	videos, next, err := s.videoRepo.List(ctx, filter, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.videoRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoPage{Videos: videos, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// getChannelForChange loads a channel and checks that the caller may modify it: its owner or an admin
+func (s *ChannelService) getChannelForChange(ctx context.Context, ref string) (*entities.Channel, error) {
+	channel, err := s.GetChannel(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeSelfOrAdmin(ctx, channel.Owner); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
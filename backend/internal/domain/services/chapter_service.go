@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ChapterService struct {
+	videoRepo repositories.VideoRepository
+}
+
+func NewChapterService(videoRepo repositories.VideoRepository) *ChapterService {
+	return &ChapterService{
+		videoRepo: videoRepo,
+	}
+}
+
+// SetChapters replaces the chapters of a video. No chapters removes them, so the chapters in the
+// description apply again.
+func (s *ChapterService) SetChapters(ctx context.Context, videoID primitive.ObjectID, chapters []entities.Chapter) (*entities.Video, error) {
+	video, err := loadVideoForChange(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range chapters {
+		chapters[i].Title = strings.TrimSpace(chapters[i].Title)
+	}
+	if err := entities.ValidateChapters(chapters, video.Duration); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err := s.videoRepo.SetChapters(ctx, video.ID, chapters); err != nil {
+		return nil, err
+	}
+	video.Chapters = chapters
+	video.UpdatedAt = time.Now()
+
+	return video, nil
+}
+
+// GetVideo returns a video the caller may watch, for its chapters
+func (s *ChapterService) GetVideo(ctx context.Context, videoID primitive.ObjectID) (*entities.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// ChapterTrack writes the chapters of a video as a WebVTT chapters track, one cue per chapter
+func ChapterTrack(video *entities.Video) ([]byte, error) {
+	chapters, _ := video.ResolveChapters()
+	if len(chapters) == 0 {
+		return nil, ErrChaptersNotFound
+	}
+	// The last cue ends with the video, so the track needs its duration
+	if video.Duration <= 0 {
+		return nil, ErrVideoBusy
+	}
+
+	var out strings.Builder
+	out.WriteString("WEBVTT\n")
+	for i, chapter := range chapters {
+		start := time.Duration(chapter.Start * float64(time.Second))
+		end := time.Duration(entities.ChapterEnd(chapters, i, video.Duration) * float64(time.Second))
+		fmt.Fprintf(&out, "\n%d\n%s --> %s\n%s\n", i+1, formatCueTime(start), formatCueTime(end), strings.ReplaceAll(chapter.Title, "-->", "->"))
+	}
+
+	return []byte(out.String()), nil
+}
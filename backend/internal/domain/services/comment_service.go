@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxCommentLength = 5000
+
+// ContentFilter inspects user-written text before it is stored. Implementations return an
+// error wrapping ErrCommentRejected, with the reason, to refuse the text.
+type ContentFilter interface {
+	Check(ctx context.Context, text string) error
+}
+
+// CommentPage is one page of a comment listing
+type CommentPage struct {
+	Comments []*entities.Comment
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every comment matching the listing, across all pages
+	Total int64
+}
+
+type CommentService struct {
+	commentRepo repositories.CommentRepository
+	videoRepo   repositories.VideoRepository
+	filters     []ContentFilter
+}
+
+func NewCommentService(commentRepo repositories.CommentRepository, videoRepo repositories.VideoRepository, filters []ContentFilter) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		videoRepo:   videoRepo,
+		filters:     filters,
+	}
+}
+
+// CreateComment adds a comment to a video the caller may watch. A reply to a reply joins
+// the thread of the top-level comment.
+func (s *CommentService) CreateComment(ctx context.Context, videoID primitive.ObjectID, parentID *primitive.ObjectID, body string) (*entities.Comment, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeCommentsView(ctx, videoID); err != nil {
+		return nil, err
+	}
+
+	body, err = s.checkBody(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.VideoID != videoID {
+			return nil, ErrCommentNotFound
+		}
+		if !parent.IsVisible() {
+			return nil, fmt.Errorf("%w: cannot reply to a deleted comment", ErrInvalidInput)
+		}
+		if parent.ParentID != nil {
+			parentID = parent.ParentID
+		}
+	}
+
+	comment := entities.NewComment(videoID, parentID, identity.UserID(), identity.User.Username, body)
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		if err := s.commentRepo.IncrementReplyCount(ctx, *parentID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return comment, nil
+}
+
+// ListComments lists a page of the top-level comments of a video the caller may watch
+func (s *CommentService) ListComments(ctx context.Context, videoID primitive.ObjectID, sort repositories.CommentSort, limit int, cursor string) (*CommentPage, error) {
+	switch sort {
+	case repositories.CommentSortNewest, repositories.CommentSortTop:
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidInput, sort)
+	}
+
+	if err := s.authorizeCommentsView(ctx, videoID); err != nil {
+		return nil, err
+	}
+
+	filter := repositories.CommentFilter{VideoID: &videoID, TopLevel: true, Listed: true}
+	return s.listComments(ctx, filter, sort, limit, cursor)
+}
+
+// ListReplies lists a page of the replies to a comment, oldest first
+func (s *CommentService) ListReplies(ctx context.Context, commentID primitive.ObjectID, limit int, cursor string) (*CommentPage, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	if err := s.authorizeCommentsView(ctx, comment.VideoID); err != nil {
+		return nil, err
+	}
+
+	filter := repositories.CommentFilter{ParentID: &comment.ID, Listed: true}
+	return s.listComments(ctx, filter, repositories.CommentSortOldest, limit, cursor)
+}
+
+// UpdateComment changes the body of a comment. Only its author can edit it.
+func (s *CommentService) UpdateComment(ctx context.Context, commentID primitive.ObjectID, body string) (*entities.Comment, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != identity.UserID() {
+		return nil, ErrForbidden
+	}
+	if !comment.IsVisible() {
+		return nil, ErrCommentNotFound
+	}
+
+	body, err = s.checkBody(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.UpdateBody(ctx, comment.ID, body); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.GetByID(ctx, comment.ID)
+}
+
+// DeleteComment hides a comment. Authors delete their own comments; moderators remove anyone's.
+// A hidden comment with replies stays in its thread as a placeholder.
+func (s *CommentService) DeleteComment(ctx context.Context, commentID primitive.ObjectID) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if !comment.IsVisible() {
+		return nil
+	}
+
+	var hidden bool
+	switch {
+	case comment.AuthorID == identity.UserID():
+		hidden, err = s.commentRepo.SetStatus(ctx, comment.ID, entities.CommentStatusDeleted, "")
+	case identity.User.HasRole(entities.RoleModerator):
+		hidden, err = s.commentRepo.SetStatus(ctx, comment.ID, entities.CommentStatusRemoved, identity.UserID())
+	default:
+		return ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+
+	// A concurrent delete already hid the comment and updated its thread
+	if hidden && comment.ParentID != nil {
+		return s.commentRepo.IncrementReplyCount(ctx, *comment.ParentID, -1)
+	}
+	return nil
+}
+
+// listComments retrieves the page of comments matching the filter that follows the cursor
+func (s *CommentService) listComments(ctx context.Context, filter repositories.CommentFilter, sort repositories.CommentSort, limit int, cursor string) (*CommentPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, next, err := s.commentRepo.List(ctx, filter, sort, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.commentRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentPage{Comments: comments, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// getComment loads a comment on a video the caller may watch
+func (s *CommentService) getComment(ctx context.Context, commentID primitive.ObjectID) (*entities.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	if err := s.authorizeCommentsView(ctx, comment.VideoID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// authorizeCommentsView checks that the caller may watch the video, and so read and write its comments
+func (s *CommentService) authorizeCommentsView(ctx context.Context, videoID primitive.ObjectID) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return ErrVideoNotFound
+	}
+	return authorizeVideoView(ctx, video)
+}
+
+// checkBody validates a comment body and runs it through the content filters
+func (s *CommentService) checkBody(ctx context.Context, body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("%w: comment cannot be empty", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidInput, maxCommentLength)
+	}
+
+	for _, filter := range s.filters {
+		if err := filter.Check(ctx, body); err != nil {
+			return "", err
+		}
+	}
+
+	return body, nil
+}
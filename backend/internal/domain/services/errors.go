@@ -0,0 +1,28 @@
+package services
+
+import "errors"
+
+// Domain errors returned by services. Handlers map them to HTTP status codes.
+var (
+	ErrFileTooLarge            = errors.New("file size exceeds the upload limit")
+	ErrStorageQuotaExceeded    = errors.New("storage quota exceeded")
+	ErrDailyUploadLimitReached = errors.New("daily upload limit reached")
+	ErrVideoBusy               = errors.New("video is still being processed")
+	ErrVideoNotFound           = errors.New("video not found")
+	ErrContentKeyNotFound      = errors.New("content key not found")
+	ErrPlaylistNotFound        = errors.New("playlist not found")
+	ErrPlaylistConflict        = errors.New("playlist was changed concurrently")
+	ErrCommentNotFound         = errors.New("comment not found")
+	ErrCommentRejected         = errors.New("comment rejected")
+	ErrChannelNotFound         = errors.New("channel not found")
+	ErrHandleTaken             = errors.New("channel handle is already taken")
+	ErrCaptionNotFound         = errors.New("caption track not found")
+	ErrChaptersNotFound        = errors.New("video has no chapters")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrInvalidInput            = errors.New("invalid input")
+	ErrUserExists              = errors.New("user already exists")
+	ErrInvalidCredentials      = errors.New("invalid username or password")
+	ErrInvalidToken            = errors.New("invalid or expired token")
+	ErrUnauthorized            = errors.New("authentication required")
+	ErrForbidden               = errors.New("access denied")
+)
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HistoryEntry is a watched video with the user's progress in it
+type HistoryEntry struct {
+	Video    *entities.Video
+	Progress *entities.WatchProgress
+}
+
+// HistoryPage is one page of a user's watch history
+type HistoryPage struct {
+	Entries []HistoryEntry
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every entry of the history, across all pages, including videos no longer available
+	Total int64
+	// Paused reports whether recording new history is paused
+	Paused bool
+}
+
+type HistoryService struct {
+	historyRepo repositories.WatchHistoryRepository
+	videoRepo   repositories.VideoRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewHistoryService(historyRepo repositories.WatchHistoryRepository, videoRepo repositories.VideoRepository, userRepo repositories.UserRepository) *HistoryService {
+	return &HistoryService{
+		historyRepo: historyRepo,
+		videoRepo:   videoRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// RecordProgress saves a user's position in a video played since startedAt. Nothing is saved once the
+// user paused their history or cleared it after the playback started, so open view sessions cannot
+// bring entries back.
+func (s *HistoryService) RecordProgress(ctx context.Context, userID string, videoID primitive.ObjectID, position, duration float64, startedAt time.Time) error {
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.RecordsHistory(startedAt) {
+		return nil
+	}
+
+	return s.historyRepo.Save(ctx, entities.NewWatchProgress(userID, videoID, position, duration))
+}
+
+// GetProgress returns the caller's progress in a video, or nil for anonymous callers and unwatched videos
+func (s *HistoryService) GetProgress(ctx context.Context, videoID primitive.ObjectID) (*entities.WatchProgress, error) {
+	identity := IdentityFromContext(ctx)
+	if identity == nil {
+		return nil, nil
+	}
+	return s.historyRepo.Get(ctx, identity.UserID(), videoID)
+}
+
+// ListHistory lists a page of the caller's watch history, most recently watched first.
+// Videos the caller can no longer watch are left out.
+func (s *HistoryService) ListHistory(ctx context.Context, limit int, cursor string) (*HistoryPage, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, next, err := s.historyRepo.List(ctx, identity.UserID(), limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.historyRepo.Count(ctx, identity.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	videoIDs := make([]primitive.ObjectID, len(progress))
+	for i, entry := range progress {
+		videoIDs[i] = entry.VideoID
+	}
+
+	videos, err := s.videoRepo.GetByIDs(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	videosByID := make(map[primitive.ObjectID]*entities.Video, len(videos))
+	for _, video := range videos {
+		videosByID[video.ID] = video
+	}
+
+	entries := make([]HistoryEntry, 0, len(progress))
+	for _, entry := range progress {
+		video := videosByID[entry.VideoID]
+		if video == nil || authorizeVideoView(ctx, video) != nil {
+			continue
+		}
+		entries = append(entries, HistoryEntry{Video: video, Progress: entry})
+	}
+
+	return &HistoryPage{
+		Entries:    entries,
+		NextCursor: encodeCursor(next),
+		Total:      total,
+		Paused:     identity.User.HistoryPaused,
+	}, nil
+}
+
+// RemoveFromHistory deletes one video from the caller's watch history
+func (s *HistoryService) RemoveFromHistory(ctx context.Context, videoID primitive.ObjectID) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	return s.historyRepo.Delete(ctx, identity.UserID(), videoID)
+}
+
+// ClearHistory deletes the caller's whole watch history and returns how many entries were removed.
+// Playback already under way is no longer recorded.
+func (s *HistoryService) ClearHistory(ctx context.Context) (int64, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Mark the history cleared before deleting it, so open view sessions stop saving entries
+	if err := s.userRepo.SetHistorySince(ctx, identity.User.ID, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return s.historyRepo.DeleteByUserID(ctx, identity.UserID())
+}
+
+// SetPaused pauses or resumes recording the caller's watch history, including playback already under way
+func (s *HistoryService) SetPaused(ctx context.Context, paused bool) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.SetHistoryPaused(ctx, identity.User.ID, paused)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"youtube-backend/internal/domain/entities"
+)
+
+// Identity describes the authenticated caller of a request
+type Identity struct {
+	User   *entities.User
+	APIKey *entities.APIKey // set when the caller authenticated with an API key
+}
+
+type identityContextKey struct{}
+
+// WithIdentity returns a context carrying the authenticated identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the authenticated identity, or nil for anonymous requests
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// UserID returns the hex ID of the authenticated user
+func (i *Identity) UserID() string {
+	return i.User.ID.Hex()
+}
+
+// HasScope checks if the identity may perform actions covered by the scope.
+// User sessions carry every scope; API keys only those they were granted.
+func (i *Identity) HasScope(scope string) bool {
+	if i.APIKey == nil {
+		return true
+	}
+	return i.APIKey.HasScope(scope)
+}
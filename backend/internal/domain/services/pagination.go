@@ -0,0 +1,70 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VideoPage is one page of a video listing
+type VideoPage struct {
+	Videos []*entities.Video
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every video matching the listing, across all pages
+	Total int64
+}
+
+// JobPage is one page of a job listing
+type JobPage struct {
+	Jobs []*entities.Job
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every job matching the listing, across all pages
+	Total int64
+}
+
+// cursorToken is the serialized form of a page cursor
+type cursorToken struct {
+	Score     float64            `json:"s,omitempty"`
+	CreatedAt time.Time          `json:"t"`
+	ID        primitive.ObjectID `json:"id"`
+}
+
+// encodeCursor turns a page cursor into an opaque token for clients, or an empty string for no cursor
+func encodeCursor(cursor *repositories.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(cursorToken{Score: cursor.Score, CreatedAt: cursor.CreatedAt, ID: cursor.ID})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor. An empty token starts from the first page.
+func decodeCursor(token string) (*repositories.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+	}
+
+	var parsed cursorToken
+	if err := json.Unmarshal(data, &parsed); err != nil || parsed.ID.IsZero() {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+	}
+
+	return &repositories.PageCursor{Score: parsed.Score, CreatedAt: parsed.CreatedAt, ID: parsed.ID}, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	createdAt := time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor *repositories.PageCursor
+	}{
+		{name: "newest first", cursor: &repositories.PageCursor{CreatedAt: createdAt, ID: id}},
+		{name: "ranked", cursor: &repositories.PageCursor{Score: 12.75, CreatedAt: createdAt, ID: id}},
+		{name: "negative score", cursor: &repositories.PageCursor{Score: -3, CreatedAt: createdAt, ID: id}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := encodeCursor(tt.cursor)
+			if token == "" {
+				t.Fatal("encodeCursor() returned an empty token")
+			}
+
+			got, err := decodeCursor(token)
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if got.Score != tt.cursor.Score || !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.ID != tt.cursor.ID {
+				t.Errorf("decodeCursor() = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestEncodeCursorNil(t *testing.T) {
+	if token := encodeCursor(nil); token != "" {
+		t.Errorf("encodeCursor(nil) = %q, want empty", token)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "empty starts from the first page", token: "", wantNil: true},
+		{name: "not base64", token: "not a cursor!", wantErr: true},
+		{name: "not JSON", token: base64.RawURLEncoding.EncodeToString([]byte("cursor")), wantErr: true},
+		{name: "missing ID", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-03-01T12:00:00Z"}`)), wantErr: true},
+		{name: "invalid ID", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-03-01T12:00:00Z","id":"xyz"}`)), wantErr: true},
+		{name: "valid", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-03-01T12:00:00Z","id":"65e1a2b3c4d5e6f708192a3b"}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("decodeCursor() error = %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("decodeCursor() = %+v, want nil: %v", got, tt.wantNil)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlaybackClaims are the facts a playback token vouches for
+type PlaybackClaims struct {
+	VideoID   primitive.ObjectID
+	ExpiresAt time.Time
+	ClientIP  string // empty when the token is not bound to an address
+	SessionID string // empty when the token is not bound to a session
+}
+
+// PlaybackTokenSigner signs and verifies playback tokens
+type PlaybackTokenSigner interface {
+	Sign(claims PlaybackClaims) (string, error)
+	Verify(token string) (*PlaybackClaims, error)
+}
+
+// ContentKeyUnwrapper decrypts content keys stored wrapped in the database
+type ContentKeyUnwrapper interface {
+	Unwrap(wrapped, nonce []byte) ([]byte, error)
+}
+
+// PlaybackGrant is an issued playback token
+type PlaybackGrant struct {
+	Video     *entities.Video
+	Token     string
+	ExpiresAt time.Time
+}
+
+type PlaybackService struct {
+	videoRepo      repositories.VideoRepository
+	contentKeyRepo repositories.ContentKeyRepository
+	signer         PlaybackTokenSigner
+	keyUnwrapper   ContentKeyUnwrapper // nil when encrypted playback is not configured
+	tokenTTL       time.Duration
+	bindIP         bool
+	bindSession    bool
+}
+
+func NewPlaybackService(videoRepo repositories.VideoRepository, contentKeyRepo repositories.ContentKeyRepository, signer PlaybackTokenSigner, keyUnwrapper ContentKeyUnwrapper, tokenTTL time.Duration, bindIP, bindSession bool) *PlaybackService {
+	return &PlaybackService{
+		videoRepo:      videoRepo,
+		contentKeyRepo: contentKeyRepo,
+		signer:         signer,
+		keyUnwrapper:   keyUnwrapper,
+		tokenTTL:       tokenTTL,
+		bindIP:         bindIP,
+		bindSession:    bindSession,
+	}
+}
+
+// BindsSession reports whether tokens are bound to a playback session
+func (s *PlaybackService) BindsSession() bool {
+	return s.bindSession
+}
+
+// IssueToken grants the caller a short-lived token to play the video
+func (s *PlaybackService) IssueToken(ctx context.Context, videoID primitive.ObjectID, clientIP, sessionID string) (*PlaybackGrant, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	claims := PlaybackClaims{
+		VideoID:   video.ID,
+		ExpiresAt: time.Now().Add(s.tokenTTL).Truncate(time.Second),
+	}
+	if s.bindIP {
+		claims.ClientIP = clientIP
+	}
+	if s.bindSession {
+		claims.SessionID = sessionID
+	}
+
+	token, err := s.signer.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaybackGrant{Video: video, Token: token, ExpiresAt: claims.ExpiresAt}, nil
+}
+
+// GetVideoWithToken verifies a playback token for the request and returns the video it grants access to
+func (s *PlaybackService) GetVideoWithToken(ctx context.Context, videoID primitive.ObjectID, token, clientIP, sessionID string) (*entities.Video, error) {
+	if err := s.verifyToken(videoID, token, clientIP, sessionID); err != nil {
+		return nil, err
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil || video.IsTrashed() {
+		return nil, ErrVideoNotFound
+	}
+
+	return video, nil
+}
+
+// GetContentKey returns the plaintext AES-128 key of an encrypted video to a holder of a valid playback token
+func (s *PlaybackService) GetContentKey(ctx context.Context, videoID primitive.ObjectID, keyID, token, clientIP, sessionID string) ([]byte, error) {
+	if err := s.verifyToken(videoID, token, clientIP, sessionID); err != nil {
+		return nil, err
+	}
+
+	if s.keyUnwrapper == nil {
+		return nil, errors.New("content key secret is not configured")
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil || video.IsTrashed() {
+		return nil, ErrVideoNotFound
+	}
+
+	key, err := s.contentKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	// A token for one video must not unlock the keys of another
+	if key == nil || key.VideoID != videoID {
+		return nil, ErrContentKeyNotFound
+	}
+
+	return s.keyUnwrapper.Unwrap(key.WrappedKey, key.Nonce)
+}
+
+// verifyToken checks that a playback token grants the request access to the video
+func (s *PlaybackService) verifyToken(videoID primitive.ObjectID, token, clientIP, sessionID string) error {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if claims.VideoID != videoID || !time.Now().Before(claims.ExpiresAt) {
+		return ErrInvalidToken
+	}
+	if claims.ClientIP != "" && claims.ClientIP != clientIP {
+		return ErrInvalidToken
+	}
+	if claims.SessionID != "" && claims.SessionID != sessionID {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// NewPlaybackSessionID creates a random ID for a playback session
+func NewPlaybackSessionID() (string, error) {
+	return generateToken()
+}
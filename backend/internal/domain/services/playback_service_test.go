@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// claimsSigner verifies a token by looking up the claims it was issued for
+type claimsSigner map[string]PlaybackClaims
+
+func (s claimsSigner) Sign(claims PlaybackClaims) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (s claimsSigner) Verify(token string) (*PlaybackClaims, error) {
+	claims, ok := s[token]
+	if !ok {
+		return nil, errors.New("invalid playback token signature")
+	}
+	return &claims, nil
+}
+
+func TestVerifyToken(t *testing.T) {
+	videoID := primitive.NewObjectID()
+	future := time.Now().Add(time.Hour)
+
+	signer := claimsSigner{
+		"unbound": {VideoID: videoID, ExpiresAt: future},
+		"expired": {VideoID: videoID, ExpiresAt: time.Now().Add(-time.Second)},
+		"other":   {VideoID: primitive.NewObjectID(), ExpiresAt: future},
+		"bound":   {VideoID: videoID, ExpiresAt: future, ClientIP: "203.0.113.7", SessionID: "session-1"},
+	}
+	service := NewPlaybackService(nil, nil, signer, nil, time.Hour, true, true)
+
+	tests := []struct {
+		name      string
+		token     string
+		clientIP  string
+		sessionID string
+		wantErr   bool
+	}{
+		{name: "valid unbound token", token: "unbound", clientIP: "198.51.100.1"},
+		{name: "valid bound token", token: "bound", clientIP: "203.0.113.7", sessionID: "session-1"},
+		{name: "bad signature", token: "forged", wantErr: true},
+		{name: "expired", token: "expired", wantErr: true},
+		{name: "other video", token: "other", wantErr: true},
+		{name: "other address", token: "bound", clientIP: "198.51.100.1", sessionID: "session-1", wantErr: true},
+		{name: "other session", token: "bound", clientIP: "203.0.113.7", sessionID: "session-2", wantErr: true},
+		{name: "missing session", token: "bound", clientIP: "203.0.113.7", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.verifyToken(videoID, tt.token, tt.clientIP, tt.sessionID)
+			if tt.wantErr && !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("verifyToken() error = %v, want ErrInvalidToken", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("verifyToken() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxPlaylistVideos = 5000
+	// nextVideoWindow is how many upcoming videos NextVideo loads at a time while skipping unplayable ones
+	nextVideoWindow = 20
+)
+
+type PlaylistService struct {
+	playlistRepo repositories.PlaylistRepository
+	videoRepo    repositories.VideoRepository
+}
+
+// PlaylistPage is one page of a playlist listing
+type PlaylistPage struct {
+	Playlists []*entities.Playlist
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+	// Total counts every playlist matching the listing, across all pages
+	Total int64
+}
+
+// CreatePlaylistInput holds the metadata of a new playlist
+type CreatePlaylistInput struct {
+	Title       string
+	Description string
+	Visibility  entities.Visibility // defaults to public
+}
+
+// UpdatePlaylistInput holds the metadata fields to change; nil fields are left as they are
+type UpdatePlaylistInput struct {
+	Title       *string
+	Description *string
+	Visibility  *entities.Visibility
+}
+
+func NewPlaylistService(playlistRepo repositories.PlaylistRepository, videoRepo repositories.VideoRepository) *PlaylistService {
+	return &PlaylistService{
+		playlistRepo: playlistRepo,
+		videoRepo:    videoRepo,
+	}
+}
+
+// CreatePlaylist creates an empty playlist owned by the caller
+func (s *PlaylistService) CreatePlaylist(ctx context.Context, input CreatePlaylistInput) (*entities.Playlist, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = entities.VisibilityPublic
+	}
+	if !visibility.IsValid() {
+		return nil, fmt.Errorf("%w: unknown visibility %q", ErrInvalidInput, visibility)
+	}
+
+	playlist := entities.NewPlaylist(identity.UserID(), title, input.Description, visibility)
+	if err := s.playlistRepo.Create(ctx, playlist); err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+// GetPlaylist returns a playlist and, in playlist order, the videos in it the caller may watch
+func (s *PlaylistService) GetPlaylist(ctx context.Context, id primitive.ObjectID) (*entities.Playlist, []*entities.Video, error) {
+	playlist, err := s.getPlaylist(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	videos, err := s.viewableVideos(ctx, playlist.VideoIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return playlist, videos, nil
+}
+
+// ListPlaylists lists a page of playlists, optionally of one owner. Private and unlisted playlists
+// are only listed for their owner, moderators and admins.
+func (s *PlaylistService) ListPlaylists(ctx context.Context, owner string, limit int, cursor string) (*PlaylistPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.PlaylistFilter{Owner: owner, PublicOnly: true}
+	if identity := IdentityFromContext(ctx); identity != nil {
+		if (owner != "" && owner == identity.UserID()) || identity.User.HasRole(entities.RoleModerator) {
+			filter.PublicOnly = false
+		}
+	}
+
+	playlists, next, err := s.playlistRepo.List(ctx, filter, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.playlistRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaylistPage{Playlists: playlists, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// UpdatePlaylist changes the title, description or visibility of a playlist
+func (s *PlaylistService) UpdatePlaylist(ctx context.Context, id primitive.ObjectID, input UpdatePlaylistInput) (*entities.Playlist, error) {
+	playlist, err := s.getPlaylistForChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		if strings.TrimSpace(*input.Title) == "" {
+			return nil, fmt.Errorf("%w: title cannot be empty", ErrInvalidInput)
+		}
+		playlist.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Description != nil {
+		playlist.Description = *input.Description
+	}
+	if input.Visibility != nil {
+		if !input.Visibility.IsValid() {
+			return nil, fmt.Errorf("%w: unknown visibility %q", ErrInvalidInput, *input.Visibility)
+		}
+		playlist.Visibility = *input.Visibility
+	}
+
+	if err := s.playlistRepo.UpdateMetadata(ctx, playlist.ID, playlist.Title, playlist.Description, playlist.Visibility); err != nil {
+		return nil, err
+	}
+
+	return s.playlistRepo.GetByID(ctx, playlist.ID)
+}
+
+// DeletePlaylist deletes a playlist. The videos in it are not affected.
+func (s *PlaylistService) DeletePlaylist(ctx context.Context, id primitive.ObjectID) error {
+	playlist, err := s.getPlaylistForChange(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.playlistRepo.Delete(ctx, playlist.ID)
+}
+
+// AddVideo inserts a video the caller may watch at position, or appends it when position is nil
+func (s *PlaylistService) AddVideo(ctx context.Context, id, videoID primitive.ObjectID, position *int) (*entities.Playlist, error) {
+	playlist, err := s.getPlaylistForChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	if playlist.IndexOf(videoID) >= 0 {
+		return nil, fmt.Errorf("%w: video is already in the playlist", ErrInvalidInput)
+	}
+	if len(playlist.VideoIDs) >= maxPlaylistVideos {
+		return nil, fmt.Errorf("%w: a playlist can hold at most %d videos", ErrInvalidInput, maxPlaylistVideos)
+	}
+	if position != nil && *position < 0 {
+		return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+
+	added, err := s.playlistRepo.AddVideo(ctx, playlist.ID, videoID, position, maxPlaylistVideos)
+	if err != nil {
+		return nil, err
+	}
+	// The video was added, the playlist filled up or the playlist deleted since it was loaded
+	if !added {
+		return nil, ErrPlaylistConflict
+	}
+
+	return s.playlistRepo.GetByID(ctx, playlist.ID)
+}
+
+// CheckPlaylistChange checks that the playlist exists and the caller may modify it
+func (s *PlaylistService) CheckPlaylistChange(ctx context.Context, id primitive.ObjectID) error {
+	_, err := s.getPlaylistForChange(ctx, id)
+	return err
+}
+
+// RemoveVideo takes a video out of a playlist
+func (s *PlaylistService) RemoveVideo(ctx context.Context, id, videoID primitive.ObjectID) (*entities.Playlist, error) {
+	playlist, err := s.getPlaylistForChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.playlistRepo.RemoveVideo(ctx, playlist.ID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrVideoNotFound
+	}
+
+	return s.playlistRepo.GetByID(ctx, playlist.ID)
+}
+
+// ReorderVideos puts the videos of a playlist in a new order. The order must contain exactly
+// the videos of the playlist at the given version; if the playlist changed since, ErrPlaylistConflict
+// is returned and the client should reload it and try again.
+func (s *PlaylistService) ReorderVideos(ctx context.Context, id primitive.ObjectID, version int, videoIDs []primitive.ObjectID) (*entities.Playlist, error) {
+	playlist, err := s.getPlaylistForChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if playlist.Version != version {
+		return nil, ErrPlaylistConflict
+	}
+	if !samePlaylistVideos(playlist.VideoIDs, videoIDs) {
+		return nil, fmt.Errorf("%w: the new order must contain exactly the videos of the playlist", ErrInvalidInput)
+	}
+
+	reordered, err := s.playlistRepo.ReorderVideos(ctx, playlist.ID, version, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !reordered {
+		return nil, ErrPlaylistConflict
+	}
+
+	return s.playlistRepo.GetByID(ctx, playlist.ID)
+}
+
+// NextVideo returns the first ready video the caller may watch that follows the given video in
+// the playlist, or the first one when after is nil. It returns nil at the end of the playlist.
+func (s *PlaylistService) NextVideo(ctx context.Context, id primitive.ObjectID, after *primitive.ObjectID) (*entities.Video, error) {
+	playlist, err := s.getPlaylist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if after != nil {
+		index := playlist.IndexOf(*after)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: video is not in the playlist", ErrInvalidInput)
+		}
+		start = index + 1
+	}
+
+	// Skip private, trashed and unfinished videos without loading the whole playlist
+	for start < len(playlist.VideoIDs) {
+		end := min(start+nextVideoWindow, len(playlist.VideoIDs))
+		videos, err := s.viewableVideos(ctx, playlist.VideoIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, video := range videos {
+			if video.Status == entities.VideoStatusReady {
+				return video, nil
+			}
+		}
+		start = end
+	}
+
+	return nil, nil
+}
+
+// getPlaylist loads a playlist the caller may see
+func (s *PlaylistService) getPlaylist(ctx context.Context, id primitive.ObjectID) (*entities.Playlist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil {
+		return nil, ErrPlaylistNotFound
+	}
+
+	if err := authorizePlaylistView(ctx, playlist); err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+// getPlaylistForChange loads a playlist and checks that the caller may modify it
+func (s *PlaylistService) getPlaylistForChange(ctx context.Context, id primitive.ObjectID) (*entities.Playlist, error) {
+	playlist, err := s.getPlaylist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizePlaylistChange(ctx, playlist); err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+// viewableVideos loads videos in the given order, leaving out those the caller may not watch
+func (s *PlaylistService) viewableVideos(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Video, error) {
+	if len(ids) == 0 {
+		return []*entities.Video{}, nil
+	}
+
+	videos, err := s.videoRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[primitive.ObjectID]*entities.Video, len(videos))
+	for _, video := range videos {
+		byID[video.ID] = video
+	}
+
+	ordered := make([]*entities.Video, 0, len(ids))
+	for _, id := range ids {
+		video, ok := byID[id]
+		if !ok || authorizeVideoView(ctx, video) != nil {
+			continue
+		}
+		ordered = append(ordered, video)
+	}
+
+	return ordered, nil
+}
+
+// samePlaylistVideos reports whether two video lists hold the same videos, each once
+func samePlaylistVideos(current, proposed []primitive.ObjectID) bool {
+	if len(current) != len(proposed) {
+		return false
+	}
+
+	remaining := make(map[primitive.ObjectID]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range proposed {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+
+	return true
+}
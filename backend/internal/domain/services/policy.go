@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+)
+
+// The functions below form the access policy. Services call them with the request
+// context before acting, so handlers never make access decisions themselves.
+
+// requireIdentity returns the authenticated identity or ErrUnauthorized
+func requireIdentity(ctx context.Context) (*Identity, error) {
+	identity := IdentityFromContext(ctx)
+	if identity == nil {
+		return nil, ErrUnauthorized
+	}
+	return identity, nil
+}
+
+// requireRole checks that the caller has at least the given role
+func requireRole(ctx context.Context, role entities.UserRole) (*Identity, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !identity.User.HasRole(role) {
+		return nil, ErrForbidden
+	}
+	return identity, nil
+}
+
+// authorizeUpload checks that the caller may upload videos
+func authorizeUpload(ctx context.Context) (*Identity, error) {
+	return requireRole(ctx, entities.RoleUploader)
+}
+
+// authorizeVideoChange checks that the caller may modify or reprocess the video: its uploader or an admin
+func authorizeVideoChange(ctx context.Context, video *entities.Video) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	if identity.User.HasRole(entities.RoleAdmin) {
+		return nil
+	}
+	if identity.User.HasRole(entities.RoleUploader) && video.UploadedBy == identity.UserID() {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// authorizeOwnerOrModerator checks that the caller owns a resource or is a moderator or admin
+func authorizeOwnerOrModerator(ctx context.Context, ownerID string) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	if ownerID == identity.UserID() || identity.User.HasRole(entities.RoleModerator) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// authorizeSelfOrAdmin checks that the caller is the given user or an admin
+func authorizeSelfOrAdmin(ctx context.Context, userID string) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	if userID == identity.UserID() || identity.User.HasRole(entities.RoleAdmin) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// authorizeVideoView checks that the caller may watch the video. Private and not yet released
+// videos are only visible to their uploader, moderators and admins; to everyone else they don't exist.
+func authorizeVideoView(ctx context.Context, video *entities.Video) error {
+	// Trashed videos are hidden from everyone, including their uploader, until restored
+	if video.IsTrashed() {
+		return ErrVideoNotFound
+	}
+	if video.IsWatchableByAnyone(time.Now()) {
+		return nil
+	}
+	if identity := IdentityFromContext(ctx); identity != nil {
+		if video.UploadedBy == identity.UserID() || identity.User.HasRole(entities.RoleModerator) {
+			return nil
+		}
+	}
+	return ErrVideoNotFound
+}
+
+// authorizePlaylistView checks that the caller may see the playlist. Private playlists are only
+// visible to their owner, moderators and admins; to everyone else they don't exist.
+func authorizePlaylistView(ctx context.Context, playlist *entities.Playlist) error {
+	if playlist.Visibility != entities.VisibilityPrivate {
+		return nil
+	}
+	if identity := IdentityFromContext(ctx); identity != nil {
+		if playlist.Owner == identity.UserID() || identity.User.HasRole(entities.RoleModerator) {
+			return nil
+		}
+	}
+	return ErrPlaylistNotFound
+}
+
+// authorizePlaylistChange checks that the caller may modify the playlist: its owner or an admin
+func authorizePlaylistChange(ctx context.Context, playlist *entities.Playlist) error {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	if playlist.Owner == identity.UserID() || identity.User.HasRole(entities.RoleAdmin) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// listingFilter restricts video listings to what the caller may discover: listed videos,
+// the caller's own videos, and everything for moderators and admins
+func listingFilter(ctx context.Context) repositories.VideoFilter {
+	identity := IdentityFromContext(ctx)
+	if identity == nil {
+		return repositories.VideoFilter{ListedOnly: true}
+	}
+	if identity.User.HasRole(entities.RoleModerator) {
+		return repositories.VideoFilter{}
+	}
+	return repositories.VideoFilter{ListedOnly: true, Viewer: identity.UserID()}
+}
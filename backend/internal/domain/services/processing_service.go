@@ -24,7 +24,16 @@ func NewProcessingService(jobRepo repositories.JobRepository, videoRepo reposito
 
 // GetJob retrieves a job by ID
 func (s *ProcessingService) GetJob(ctx context.Context, id primitive.ObjectID) (*entities.Job, error) {
-	return s.jobRepo.GetByID(ctx, id)
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeJobAccess(ctx, job.VideoID); err != nil {
+		return nil, err
+	}
+
+	return job, nil
 }
 
 // GetPendingJobs retrieves pending jobs for processing
@@ -75,7 +84,7 @@ func (s *ProcessingService) CompleteJob(ctx context.Context, jobID primitive.Obj
 	}
 
 	// Check if all jobs for this video are completed
-	return s.checkVideoCompletion(ctx, job.VideoID)
+	return s.checkVideoCompletion(ctx, job)
 }
 
 // FailJob marks a job as failed
@@ -91,55 +100,102 @@ func (s *ProcessingService) FailJob(ctx context.Context, jobID primitive.ObjectI
 	}
 
 	// Mark video as failed if any job fails
-	return s.markVideoAsFailed(ctx, job.VideoID, errorMessage)
+	return s.markVideoAsFailed(ctx, job, errorMessage)
 }
 
-// GetJobsByVideoID retrieves all jobs for a video
-func (s *ProcessingService) GetJobsByVideoID(ctx context.Context, videoID primitive.ObjectID) ([]*entities.Job, error) {
-	return s.jobRepo.GetByVideoID(ctx, videoID)
+// GetJobsByVideoID retrieves a page of the jobs of a video, newest first
+func (s *ProcessingService) GetJobsByVideoID(ctx context.Context, videoID primitive.ObjectID, limit int, cursor string) (*JobPage, error) {
+	if err := s.authorizeJobAccess(ctx, videoID); err != nil {
+		return nil, err
+	}
+
+	return s.listJobs(ctx, repositories.JobFilter{VideoID: &videoID}, limit, cursor)
+}
+
+// GetActiveJobs retrieves a page of the currently processing jobs. Only admins can see them.
+func (s *ProcessingService) GetActiveJobs(ctx context.Context, limit int, cursor string) (*JobPage, error) {
+	if _, err := requireRole(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return s.listJobs(ctx, repositories.JobFilter{Status: entities.JobStatusProcessing}, limit, cursor)
+}
+
+// listJobs retrieves the page of jobs matching the filter that follows the cursor
+func (s *ProcessingService) listJobs(ctx context.Context, filter repositories.JobFilter, limit int, cursor string) (*JobPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, next, err := s.jobRepo.List(ctx, filter, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.jobRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobPage{Jobs: jobs, NextCursor: encodeCursor(next), Total: total}, nil
 }
 
-// GetActiveJobs retrieves all currently processing jobs
-func (s *ProcessingService) GetActiveJobs(ctx context.Context) ([]*entities.Job, error) {
-	return s.jobRepo.GetActiveJobs(ctx)
+// authorizeJobAccess checks that the caller may read the jobs of a video: its uploader, a moderator or an admin
+func (s *ProcessingService) authorizeJobAccess(ctx context.Context, videoID primitive.ObjectID) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	return authorizeOwnerOrModerator(ctx, video.UploadedBy)
 }
 
-// checkVideoCompletion checks if all jobs for a video are completed and updates video status
-func (s *ProcessingService) checkVideoCompletion(ctx context.Context, videoID primitive.ObjectID) error {
-	jobs, err := s.jobRepo.GetByVideoID(ctx, videoID)
+// checkVideoCompletion checks if all jobs for the job's source revision are completed and updates video status
+func (s *ProcessingService) checkVideoCompletion(ctx context.Context, completedJob *entities.Job) error {
+	jobs, err := s.jobRepo.GetByVideoID(ctx, completedJob.VideoID)
 	if err != nil {
 		return fmt.Errorf("failed to get jobs for video: %w", err)
 	}
 
 	allCompleted := true
 	for _, job := range jobs {
-		if !job.IsCompleted() {
+		if job.Revision == completedJob.Revision && !job.IsCompleted() {
 			allCompleted = false
 			break
 		}
 	}
 
 	if allCompleted {
-		// Update video status to ready
-		video, err := s.videoRepo.GetByID(ctx, videoID)
+		video, err := s.videoRepo.GetByID(ctx, completedJob.VideoID)
 		if err != nil {
 			return fmt.Errorf("failed to get video: %w", err)
 		}
 
-		video.UpdateStatus(entities.VideoStatusReady)
+		// Swap in renditions of a replaced source, otherwise just mark the video ready
+		if video.PendingRevision > 0 && video.PendingRevision == completedJob.Revision {
+			video.PromotePendingRevision()
+		} else {
+			video.UpdateStatus(entities.VideoStatusReady)
+		}
 		return s.videoRepo.Update(ctx, video)
 	}
 
 	return nil
 }
 
-// markVideoAsFailed marks a video as failed
-func (s *ProcessingService) markVideoAsFailed(ctx context.Context, videoID primitive.ObjectID, errorMessage string) error {
-	video, err := s.videoRepo.GetByID(ctx, videoID)
+// markVideoAsFailed marks a video as failed. A failed replacement keeps the current renditions live.
+func (s *ProcessingService) markVideoAsFailed(ctx context.Context, job *entities.Job, errorMessage string) error {
+	video, err := s.videoRepo.GetByID(ctx, job.VideoID)
 	if err != nil {
 		return fmt.Errorf("failed to get video: %w", err)
 	}
 
+	if video.PendingRevision > 0 && video.PendingRevision == job.Revision {
+		video.PendingError = errorMessage
+		video.UpdatedAt = job.UpdatedAt
+		return s.videoRepo.Update(ctx, video)
+	}
+
 	video.UpdateStatus(entities.VideoStatusFailed)
 	return s.videoRepo.Update(ctx, video)
 }
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type QuotaService struct {
+	userRepo      repositories.UserRepository
+	planRepo      repositories.PlanRepository
+	videoRepo     repositories.VideoRepository
+	defaultPlan   string
+	defaultLimits entities.UploadLimits
+}
+
+func NewQuotaService(userRepo repositories.UserRepository, planRepo repositories.PlanRepository, videoRepo repositories.VideoRepository, defaultPlan string, defaultLimits entities.UploadLimits) *QuotaService {
+	return &QuotaService{
+		userRepo:      userRepo,
+		planRepo:      planRepo,
+		videoRepo:     videoRepo,
+		defaultPlan:   defaultPlan,
+		defaultLimits: defaultLimits,
+	}
+}
+
+// GetLimits resolves the upload limits for an uploader.
+// Per-user overrides win over the user's plan, which wins over the default plan.
+func (s *QuotaService) GetLimits(ctx context.Context, uploadedBy string) (entities.UploadLimits, error) {
+	planName := s.defaultPlan
+
+	user, err := s.findUser(ctx, uploadedBy)
+	if err != nil {
+		return entities.UploadLimits{}, err
+	}
+	if user != nil {
+		if user.Limits != nil {
+			return *user.Limits, nil
+		}
+		if user.Plan != "" {
+			planName = user.Plan
+		}
+	}
+
+	plan, err := s.planRepo.GetByName(ctx, planName)
+	if err != nil {
+		return entities.UploadLimits{}, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil {
+		return s.defaultLimits, nil
+	}
+
+	return plan.Limits, nil
+}
+
+// CheckUpload verifies that an upload of the given size fits into the uploader's quota
+func (s *QuotaService) CheckUpload(ctx context.Context, uploadedBy string, size int64) error {
+	limits, err := s.GetLimits(ctx, uploadedBy)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxFileSize > 0 && size > limits.MaxFileSize {
+		return fmt.Errorf("%w: %d bytes (max: %d bytes)", ErrFileTooLarge, size, limits.MaxFileSize)
+	}
+
+	if err := s.checkStorage(ctx, uploadedBy, size, limits); err != nil {
+		return err
+	}
+
+	if limits.MaxVideosPerDay > 0 {
+		count, err := s.videoRepo.CountByUploadedBySince(ctx, uploadedBy, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			return fmt.Errorf("failed to count recent uploads: %w", err)
+		}
+		if count >= int64(limits.MaxVideosPerDay) {
+			return fmt.Errorf("%w: %d videos in the last 24 hours", ErrDailyUploadLimitReached, count)
+		}
+	}
+
+	return nil
+}
+
+// CheckStorage verifies that storing size more bytes for the uploader, such as captions or a custom
+// thumbnail of an existing video, fits into their storage quota
+func (s *QuotaService) CheckStorage(ctx context.Context, uploadedBy string, size int64) error {
+	limits, err := s.GetLimits(ctx, uploadedBy)
+	if err != nil {
+		return err
+	}
+	return s.checkStorage(ctx, uploadedBy, size, limits)
+}
+
+// checkStorage verifies that size more bytes fit into the storage limit
+func (s *QuotaService) checkStorage(ctx context.Context, uploadedBy string, size int64, limits entities.UploadLimits) error {
+	if limits.MaxTotalStorage <= 0 || size <= 0 {
+		return nil
+	}
+
+	usage, err := s.videoRepo.GetStorageUsage(ctx, uploadedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get storage usage: %w", err)
+	}
+	if usage.TotalBytes()+size > limits.MaxTotalStorage {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrStorageQuotaExceeded, usage.TotalBytes(), limits.MaxTotalStorage)
+	}
+	return nil
+}
+
+// GetUsage reports the storage consumed by an uploader together with the applicable limits
+func (s *QuotaService) GetUsage(ctx context.Context, uploadedBy string) (*entities.StorageUsage, entities.UploadLimits, error) {
+	if err := authorizeSelfOrAdmin(ctx, uploadedBy); err != nil {
+		return nil, entities.UploadLimits{}, err
+	}
+
+	limits, err := s.GetLimits(ctx, uploadedBy)
+	if err != nil {
+		return nil, entities.UploadLimits{}, err
+	}
+
+	usage, err := s.videoRepo.GetStorageUsage(ctx, uploadedBy)
+	if err != nil {
+		return nil, entities.UploadLimits{}, fmt.Errorf("failed to get storage usage: %w", err)
+	}
+
+	return usage, limits, nil
+}
+
+// CountUploadsToday returns the number of videos uploaded in the last 24 hours
+func (s *QuotaService) CountUploadsToday(ctx context.Context, uploadedBy string) (int64, error) {
+	return s.videoRepo.CountByUploadedBySince(ctx, uploadedBy, time.Now().Add(-24*time.Hour))
+}
+
+// findUser looks up the uploader by ID or username. Unknown uploaders yield nil.
+func (s *QuotaService) findUser(ctx context.Context, uploadedBy string) (*entities.User, error) {
+	if id, err := primitive.ObjectIDFromHex(uploadedBy); err == nil {
+		user, err := s.userRepo.GetByID(ctx, id)
+		if err == nil {
+			return user, nil
+		}
+	}
+
+	user, err := s.userRepo.GetByUsername(ctx, uploadedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReactionSummary is a video's reaction counters with the caller's own reaction
+type ReactionSummary struct {
+	entities.ReactionCounts
+	// Mine is the caller's reaction, empty if they have none or are anonymous
+	Mine entities.ReactionType
+}
+
+type ReactionService struct {
+	reactionRepo repositories.ReactionRepository
+	videoRepo    repositories.VideoRepository
+}
+
+func NewReactionService(reactionRepo repositories.ReactionRepository, videoRepo repositories.VideoRepository) *ReactionService {
+	return &ReactionService{
+		reactionRepo: reactionRepo,
+		videoRepo:    videoRepo,
+	}
+}
+
+// GetReactions returns the reaction counters of a video the caller may watch
+func (s *ReactionService) GetReactions(ctx context.Context, videoID primitive.ObjectID) (*ReactionSummary, error) {
+	video, err := s.getViewableVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ReactionSummary{ReactionCounts: video.Reactions()}
+	if identity := IdentityFromContext(ctx); identity != nil {
+		reaction, err := s.reactionRepo.Get(ctx, videoID, identity.UserID())
+		if err != nil {
+			return nil, err
+		}
+		if reaction != nil {
+			summary.Mine = reaction.Type
+		}
+	}
+
+	return summary, nil
+}
+
+// SetReaction likes or dislikes a video, replacing the caller's previous reaction. Repeating the same
+// reaction changes nothing.
+func (s *ReactionService) SetReaction(ctx context.Context, videoID primitive.ObjectID, reactionType entities.ReactionType) (*ReactionSummary, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reactionType.IsValid() {
+		return nil, fmt.Errorf("%w: reaction must be %q or %q", ErrInvalidInput, entities.ReactionLike, entities.ReactionDislike)
+	}
+
+	video, err := s.getViewableVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.reactionRepo.Set(ctx, videoID, identity.UserID(), reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, video, previous, reactionType)
+}
+
+// RemoveReaction withdraws the caller's reaction to a video, if any
+func (s *ReactionService) RemoveReaction(ctx context.Context, videoID primitive.ObjectID) (*ReactionSummary, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := s.getViewableVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.reactionRepo.Delete(ctx, videoID, identity.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, video, previous, "")
+}
+
+// ReconcileCounters recomputes the reaction counters of every video, trashed ones included, from the
+// reactions collection and repairs those that drifted. It returns how many videos were repaired.
+func (s *ReactionService) ReconcileCounters(ctx context.Context, batchSize int) (int, error) {
+	repaired := 0
+	for _, trashed := range []bool{false, true} {
+		var after *repositories.PageCursor
+		for {
+			videos, next, err := s.videoRepo.List(ctx, repositories.VideoFilter{Trashed: trashed}, batchSize, after)
+			if err != nil {
+				return repaired, err
+			}
+
+			for _, video := range videos {
+				counts, err := s.reactionRepo.Count(ctx, video.ID)
+				if err != nil {
+					return repaired, err
+				}
+				if counts == video.Reactions() {
+					continue
+				}
+
+				// A reaction landing meanwhile makes the reset miss; the next run picks the video up again
+				reset, err := s.videoRepo.ResetReactions(ctx, video.ID, video.Reactions(), counts)
+				if err != nil {
+					return repaired, err
+				}
+				if reset {
+					repaired++
+				}
+			}
+
+			if next == nil {
+				break
+			}
+			after = next
+		}
+	}
+
+	return repaired, nil
+}
+
+// applyChange moves the video's counters from the previous reaction to the current one
+func (s *ReactionService) applyChange(ctx context.Context, video *entities.Video, previous, current entities.ReactionType) (*ReactionSummary, error) {
+	counts := video.Reactions()
+	if previous != current {
+		delta := entities.ReactionCounts{}.Add(previous, -1).Add(current, 1)
+		if err := s.videoRepo.IncrementReactions(ctx, video.ID, delta); err != nil {
+			return nil, err
+		}
+		counts.Likes += delta.Likes
+		counts.Dislikes += delta.Dislikes
+	}
+
+	return &ReactionSummary{ReactionCounts: counts, Mine: current}, nil
+}
+
+// getViewableVideo loads a video and checks that the caller may watch it
+func (s *ReactionService) getViewableVideo(ctx context.Context, videoID primitive.ObjectID) (*entities.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxTrendingVideos bounds the size of the trending ranking
+	maxTrendingVideos = 200
+	// trendingLikeWeight and trendingDislikeWeight convert reactions into views' worth of activity
+	trendingLikeWeight    = 4
+	trendingDislikeWeight = 2
+	// trendingLoadBatch bounds how many candidate videos are loaded at once
+	trendingLoadBatch = 500
+
+	// maxRelatedVideos is how many related videos are kept per video
+	maxRelatedVideos = 20
+	// relatedCatalogBatch bounds how many videos are loaded, and how many related lists are written, at once
+	relatedCatalogBatch = 500
+	// relatedIndexLimit bounds how many videos sharing a tag or channel are considered, newest first,
+	// so that very common tags don't make every video related to every other
+	relatedIndexLimit = 200
+	// coWatchPerUser is how many of each user's most recent videos count towards co-watch statistics
+	coWatchPerUser = 50
+
+	// Weights of the signals that relate two videos
+	relatedTagWeight      = 1.0
+	relatedChannelWeight  = 1.5
+	relatedCategoryWeight = 0.5
+	relatedCoWatchWeight  = 4.0
+)
+
+// RecommendationCache stores the precomputed trending ranking and related videos
+type RecommendationCache interface {
+	SetTrending(ctx context.Context, ranked []entities.ScoredVideo, ttl time.Duration) error
+	// GetTrending returns the top trending videos, best first, and the size of the ranking
+	GetTrending(ctx context.Context, limit int) ([]primitive.ObjectID, int64, error)
+	// SetRelated replaces the related videos of each video in the map, best match first
+	SetRelated(ctx context.Context, related map[primitive.ObjectID][]primitive.ObjectID, ttl time.Duration) error
+	// GetRelated returns the related videos of a video, best match first, or nil if none were computed
+	GetRelated(ctx context.Context, videoID primitive.ObjectID, limit int) ([]primitive.ObjectID, error)
+	// ClaimRefresh reports whether the caller may run the named refresh job; one caller wins per lease
+	ClaimRefresh(ctx context.Context, job string, lease time.Duration) (bool, error)
+}
+
+// RecommendationService ranks trending videos and finds related ones. Both are computed by
+// background jobs and served from the cache.
+type RecommendationService struct {
+	videoRepo     repositories.VideoRepository
+	statsRepo     repositories.ViewStatsRepository
+	reactionRepo  repositories.ReactionRepository
+	historyRepo   repositories.WatchHistoryRepository
+	cache         RecommendationCache
+	window        time.Duration // how far back activity counts towards trending
+	halfLife      time.Duration // age at which activity counts half
+	coWatchWindow time.Duration // how far back watch history counts towards related videos
+}
+
+func NewRecommendationService(videoRepo repositories.VideoRepository, statsRepo repositories.ViewStatsRepository, reactionRepo repositories.ReactionRepository, historyRepo repositories.WatchHistoryRepository, cache RecommendationCache, window, halfLife, coWatchWindow time.Duration) *RecommendationService {
+	return &RecommendationService{
+		videoRepo:     videoRepo,
+		statsRepo:     statsRepo,
+		reactionRepo:  reactionRepo,
+		historyRepo:   historyRepo,
+		cache:         cache,
+		window:        window,
+		halfLife:      halfLife,
+		coWatchWindow: coWatchWindow,
+	}
+}
+
+// Trending returns the top trending videos that anyone may watch, best first
+func (s *RecommendationService) Trending(ctx context.Context, limit int) (*VideoPage, error) {
+	ids, total, err := s.cache.GetTrending(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	videos, err := s.loadRecommendable(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoPage{Videos: videos, Total: total}, nil
+}
+
+// Related returns videos related to the given one that anyone may watch, best match first. Until the
+// background job has covered the video, videos sharing its first tag or its channel stand in.
+func (s *RecommendationService) Related(ctx context.Context, videoID primitive.ObjectID, limit int) (*VideoPage, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.cache.GetRelated(ctx, videoID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var videos []*entities.Video
+	if len(ids) > 0 {
+		videos, err = s.loadRecommendable(ctx, ids)
+	} else {
+		videos, err = s.fallbackRelated(ctx, video, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoPage{Videos: videos, Total: int64(len(videos))}, nil
+}
+
+// RefreshTrending recomputes the trending ranking from the views and reactions within the window.
+// Each day's activity is weighted down by its age with exponential decay. It returns the size of the ranking.
+func (s *RecommendationService) RefreshTrending(ctx context.Context, ttl time.Duration) (int, error) {
+	now := time.Now().UTC()
+	since := now.Add(-s.window).Truncate(24 * time.Hour)
+
+	activity := make(map[primitive.ObjectID]float64)
+	addActivity := func(videoID primitive.ObjectID, day time.Time, amount float64) {
+		// A day's activity is dated to its middle, or to now for the current day
+		at := day.Add(12 * time.Hour)
+		if at.After(now) {
+			at = now
+		}
+		activity[videoID] += amount * math.Pow(0.5, now.Sub(at).Hours()/s.halfLife.Hours())
+	}
+
+	views, err := s.statsRepo.ListViewsSince(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+	for _, day := range views {
+		addActivity(day.VideoID, day.Day, float64(day.Views))
+	}
+
+	reactions, err := s.reactionRepo.CountDailySince(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+	for _, day := range reactions {
+		addActivity(day.VideoID, day.Day, float64(trendingLikeWeight*day.Likes-trendingDislikeWeight*day.Dislikes))
+	}
+
+	ranked := make([]entities.ScoredVideo, 0, len(activity))
+	for videoID, score := range activity {
+		if score > 0 {
+			ranked = append(ranked, entities.ScoredVideo{VideoID: videoID, Score: score})
+		}
+	}
+	sortScored(ranked)
+
+	// Only videos anyone may watch can trend; walk down the ranking until it is full
+	trending := make([]entities.ScoredVideo, 0, maxTrendingVideos)
+	for start := 0; start < len(ranked) && len(trending) < maxTrendingVideos; start += trendingLoadBatch {
+		batch := ranked[start:min(start+trendingLoadBatch, len(ranked))]
+		ids := make([]primitive.ObjectID, len(batch))
+		for i, candidate := range batch {
+			ids[i] = candidate.VideoID
+		}
+
+		videos, err := s.videoRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return 0, err
+		}
+		eligible := make(map[primitive.ObjectID]bool, len(videos))
+		for _, video := range videos {
+			eligible[video.ID] = isRecommendable(video, now)
+		}
+
+		for _, candidate := range batch {
+			if eligible[candidate.VideoID] && len(trending) < maxTrendingVideos {
+				trending = append(trending, candidate)
+			}
+		}
+	}
+
+	if err := s.cache.SetTrending(ctx, trending, ttl); err != nil {
+		return 0, err
+	}
+	return len(trending), nil
+}
+
+// relatedFeatures is what RefreshRelated compares videos by
+type relatedFeatures struct {
+	tags      []string
+	channelID primitive.ObjectID
+	category  string
+}
+
+// ClaimRelatedRefresh reports whether this backend should refresh related videos now. Only one backend
+// claims each lease, so replicas don't all rebuild the catalog index at the same time.
+func (s *RecommendationService) ClaimRelatedRefresh(ctx context.Context, lease time.Duration) (bool, error) {
+	return s.cache.ClaimRefresh(ctx, "related", lease)
+}
+
+// RefreshRelated recomputes the related videos of every video anyone may watch. Videos are related by
+// shared tags, a shared channel or category, and by being watched by the same users within the co-watch
+// window. It returns how many videos got related videos.
+//
+// The tags, channel and category of the whole listed catalog are held in memory while the lists are
+// computed, which suits catalogs of up to a few hundred thousand videos; larger ones need an index-backed
+// query per video instead. Callers use ClaimRelatedRefresh so that only one backend runs it at a time.
+func (s *RecommendationService) RefreshRelated(ctx context.Context, ttl time.Duration) (int, error) {
+	now := time.Now()
+
+	// Index the catalog by tag and channel, newest videos first
+	catalog := make(map[primitive.ObjectID]relatedFeatures)
+	var order []primitive.ObjectID
+	byTag := make(map[string][]primitive.ObjectID)
+	byChannel := make(map[primitive.ObjectID][]primitive.ObjectID)
+
+	var after *repositories.PageCursor
+	for {
+		videos, next, err := s.videoRepo.List(ctx, repositories.VideoFilter{ListedOnly: true}, relatedCatalogBatch, after)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, video := range videos {
+			if !isRecommendable(video, now) {
+				continue
+			}
+			catalog[video.ID] = relatedFeatures{tags: video.Tags, channelID: video.ChannelID, category: video.Category}
+			order = append(order, video.ID)
+			for _, tag := range video.Tags {
+				if len(byTag[tag]) < relatedIndexLimit {
+					byTag[tag] = append(byTag[tag], video.ID)
+				}
+			}
+			if !video.ChannelID.IsZero() && len(byChannel[video.ChannelID]) < relatedIndexLimit {
+				byChannel[video.ChannelID] = append(byChannel[video.ChannelID], video.ID)
+			}
+		}
+
+		if next == nil {
+			break
+		}
+		after = next
+	}
+
+	// Count how often pairs of catalog videos were watched by the same user
+	groups, err := s.historyRepo.ListRecentByUser(ctx, now.Add(-s.coWatchWindow), coWatchPerUser)
+	if err != nil {
+		return 0, err
+	}
+	watchers := make(map[primitive.ObjectID]int)
+	coWatched := make(map[primitive.ObjectID]map[primitive.ObjectID]int)
+	for _, group := range groups {
+		var watched []primitive.ObjectID
+		for _, id := range group {
+			if _, ok := catalog[id]; ok {
+				watched = append(watched, id)
+				watchers[id]++
+			}
+		}
+		for _, a := range watched {
+			for _, b := range watched {
+				if a == b {
+					continue
+				}
+				if coWatched[a] == nil {
+					coWatched[a] = make(map[primitive.ObjectID]int)
+				}
+				coWatched[a][b]++
+			}
+		}
+	}
+
+	refreshed := 0
+	pending := make(map[primitive.ObjectID][]primitive.ObjectID, relatedCatalogBatch)
+	for i, videoID := range order {
+		features := catalog[videoID]
+		scores := make(map[primitive.ObjectID]float64)
+
+		for _, tag := range features.tags {
+			for _, other := range byTag[tag] {
+				scores[other] += relatedTagWeight
+			}
+		}
+		if !features.channelID.IsZero() {
+			for _, other := range byChannel[features.channelID] {
+				scores[other] += relatedChannelWeight
+			}
+		}
+		// Co-watch counts are normalized by how widely both videos are watched, so popular
+		// videos don't dominate every list
+		for other, count := range coWatched[videoID] {
+			scores[other] += relatedCoWatchWeight * float64(count) / math.Sqrt(float64(watchers[videoID]*watchers[other]))
+		}
+		delete(scores, videoID)
+
+		ranked := make([]entities.ScoredVideo, 0, len(scores))
+		for other, score := range scores {
+			if features.category != "" && catalog[other].category == features.category {
+				score += relatedCategoryWeight
+			}
+			ranked = append(ranked, entities.ScoredVideo{VideoID: other, Score: score})
+		}
+		sortScored(ranked)
+		if len(ranked) > maxRelatedVideos {
+			ranked = ranked[:maxRelatedVideos]
+		}
+
+		related := make([]primitive.ObjectID, len(ranked))
+		for i, candidate := range ranked {
+			related[i] = candidate.VideoID
+		}
+		pending[videoID] = related
+
+		if len(pending) == relatedCatalogBatch || i == len(order)-1 {
+			if err := s.cache.SetRelated(ctx, pending, ttl); err != nil {
+				return refreshed, err
+			}
+			for _, ids := range pending {
+				if len(ids) > 0 {
+					refreshed++
+				}
+			}
+			pending = make(map[primitive.ObjectID][]primitive.ObjectID, relatedCatalogBatch)
+		}
+	}
+
+	return refreshed, nil
+}
+
+// fallbackRelated lists the newest videos sharing the video's first tag, or else its channel
+func (s *RecommendationService) fallbackRelated(ctx context.Context, video *entities.Video, limit int) ([]*entities.Video, error) {
+	filter := repositories.VideoFilter{ListedOnly: true}
+	switch {
+	case len(video.Tags) > 0:
+		filter.Tag = video.Tags[0]
+	case !video.ChannelID.IsZero():
+		filter.ChannelIDs = []primitive.ObjectID{video.ChannelID}
+	default:
+		return []*entities.Video{}, nil
+	}
+
+	candidates, _, err := s.videoRepo.List(ctx, filter, limit+1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	videos := make([]*entities.Video, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID != video.ID && isRecommendable(candidate, now) && len(videos) < limit {
+			videos = append(videos, candidate)
+		}
+	}
+	return videos, nil
+}
+
+// loadRecommendable loads the videos in the given order, dropping those that can no longer be
+// recommended since the cache was computed
+func (s *RecommendationService) loadRecommendable(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Video, error) {
+	if len(ids) == 0 {
+		return []*entities.Video{}, nil
+	}
+
+	loaded, err := s.videoRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*entities.Video, len(loaded))
+	for _, video := range loaded {
+		byID[video.ID] = video
+	}
+
+	now := time.Now()
+	videos := make([]*entities.Video, 0, len(ids))
+	for _, id := range ids {
+		if video, ok := byID[id]; ok && isRecommendable(video, now) {
+			videos = append(videos, video)
+		}
+	}
+	return videos, nil
+}
+
+// isRecommendable reports whether a video may be recommended to anyone: ready, public, released and not trashed.
+// Unlisted videos are watchable by anyone with the link but never surface in recommendations.
+func isRecommendable(video *entities.Video, now time.Time) bool {
+	return video.Status == entities.VideoStatusReady && !video.IsTrashed() &&
+		video.EffectiveVisibility() == entities.VisibilityPublic && video.IsPublished(now)
+}
+
+// sortScored orders videos by descending score, newer IDs first among equal scores
+func sortScored(videos []entities.ScoredVideo) {
+	sort.Slice(videos, func(i, j int) bool {
+		if videos[i].Score != videos[j].Score {
+			return videos[i].Score > videos[j].Score
+		}
+		return videos[i].VideoID.Hex() > videos[j].VideoID.Hex()
+	})
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // registers PNG for image.Decode
+)
+
+const (
+	minThumbnailWidth  = 640
+	minThumbnailHeight = 360
+	// maxThumbnailDimension bounds decoded images, whose memory use is not limited by the file size
+	maxThumbnailDimension = 4096
+	thumbnailJPEGQuality  = 85
+)
+
+// renderThumbnails validates an uploaded JPEG or PNG image and encodes it as JPEG in every thumbnail
+// size, keeping its aspect ratio. Transparent areas become white.
+func renderThumbnails(data []byte, widths []int) ([][]byte, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, fmt.Errorf("%w: thumbnail must be a JPEG or PNG image", ErrInvalidInput)
+	}
+	if config.Width < minThumbnailWidth || config.Height < minThumbnailHeight {
+		return nil, fmt.Errorf("%w: thumbnail must be at least %dx%d pixels", ErrInvalidInput, minThumbnailWidth, minThumbnailHeight)
+	}
+	if config.Width > maxThumbnailDimension || config.Height > maxThumbnailDimension {
+		return nil, fmt.Errorf("%w: thumbnail must be at most %dx%d pixels", ErrInvalidInput, maxThumbnailDimension, maxThumbnailDimension)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: thumbnail image is corrupt", ErrInvalidInput)
+	}
+
+	bounds := decoded.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), decoded, bounds.Min, draw.Over)
+
+	rendered := make([][]byte, len(widths))
+	for i, width := range widths {
+		var out bytes.Buffer
+		if err := jpeg.Encode(&out, scaleToWidth(src, width), &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		rendered[i] = out.Bytes()
+	}
+	return rendered, nil
+}
+
+// scaleToWidth shrinks an image to the width, averaging the source pixels each target pixel covers.
+// Images no wider than the width are returned as they are.
+func scaleToWidth(src *image.RGBA, width int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= width {
+		return src
+	}
+	height := max(1, (sh*width+sw/2)/sw)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r, g, b, a = r+uint32(p[0]), g+uint32(p[1]), b+uint32(p[2]), a+uint32(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n)
+		}
+	}
+	return dst
+}
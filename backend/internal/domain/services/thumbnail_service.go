@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxThumbnailFileSize bounds uploaded custom thumbnails
+const MaxThumbnailFileSize = 2 << 20 // 2MB
+
+type ThumbnailService struct {
+	videoRepo    repositories.VideoRepository
+	imageStore   ImageStore
+	quotaService *QuotaService
+}
+
+func NewThumbnailService(videoRepo repositories.VideoRepository, imageStore ImageStore, quotaService *QuotaService) *ThumbnailService {
+	return &ThumbnailService{
+		videoRepo:    videoRepo,
+		imageStore:   imageStore,
+		quotaService: quotaService,
+	}
+}
+
+// SelectThumbnail selects one of the video's generated thumbnails or its custom thumbnail as the one
+// shown for it. An empty object restores the default, see entities.Video.PrimaryThumbnailObject.
+func (s *ThumbnailService) SelectThumbnail(ctx context.Context, videoID primitive.ObjectID, object string) (*entities.Video, error) {
+	video, err := loadVideoForChange(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if object != "" && !video.HasThumbnail(object) {
+		return nil, fmt.Errorf("%w: %q is not a thumbnail of the video", ErrInvalidInput, object)
+	}
+
+	if err := s.videoRepo.SetPrimaryThumbnail(ctx, video.ID, object); err != nil {
+		return nil, err
+	}
+	video.PrimaryThumbnail = object
+	video.UpdatedAt = time.Now()
+
+	return video, nil
+}
+
+// UploadCustomThumbnail stores a JPEG or PNG image in every thumbnail size as the video's custom
+// thumbnail and selects it, removing the custom thumbnail it replaces. The stored sizes count towards
+// the uploader's storage quota.
+func (s *ThumbnailService) UploadCustomThumbnail(ctx context.Context, videoID primitive.ObjectID, data []byte) (*entities.Video, error) {
+	video, err := loadVideoForChange(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxThumbnailFileSize {
+		return nil, fmt.Errorf("%w: thumbnail must be at most %d bytes", ErrInvalidInput, MaxThumbnailFileSize)
+	}
+
+	widths := make([]int, len(entities.ThumbnailSizes))
+	for i, size := range entities.ThumbnailSizes {
+		widths[i] = size.Width()
+	}
+	images, err := renderThumbnails(data, widths)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replaced custom thumbnail frees its own bytes
+	added := int64(0)
+	for _, image := range images {
+		added += int64(len(image))
+	}
+	if video.CustomThumbnail != nil {
+		added -= video.CustomThumbnail.Size
+	}
+	if err := s.quotaService.CheckStorage(ctx, video.UploadedBy, added); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	thumbnail := entities.CustomThumbnail{Object: video.CustomThumbnailObjectName(now), UploadedAt: now}
+	for i, size := range entities.ThumbnailSizes {
+		objectName := entities.ThumbnailObjectName(thumbnail.Object, size)
+		if err := s.imageStore.UploadThumbnail(ctx, objectName, bytes.NewReader(images[i]), int64(len(images[i])), "image/jpeg"); err != nil {
+			s.deleteThumbnail(ctx, thumbnail.Object)
+			return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+		}
+		thumbnail.Size += int64(len(images[i]))
+	}
+
+	previous, err := s.videoRepo.SetCustomThumbnail(ctx, video.ID, thumbnail)
+	if err != nil {
+		s.deleteThumbnail(ctx, thumbnail.Object)
+		return nil, err
+	}
+	if previous != nil && previous.Object != thumbnail.Object {
+		s.deleteThumbnail(ctx, previous.Object)
+		video.ThumbnailsSize -= previous.Size
+	}
+
+	video.CustomThumbnail = &thumbnail
+	video.PrimaryThumbnail = thumbnail.Object
+	video.ThumbnailsSize += thumbnail.Size
+	video.UpdatedAt = now
+
+	return video, nil
+}
+
+// deleteThumbnail removes a thumbnail in every size. A leftover object only wastes space, so failed
+// deletes are ignored; purging the video removes anything left behind.
+func (s *ThumbnailService) deleteThumbnail(ctx context.Context, object string) {
+	for _, size := range entities.ThumbnailSizes {
+		_ = s.imageStore.DeleteThumbnail(ctx, entities.ThumbnailObjectName(object, size))
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"youtube-backend/internal/domain/entities"
 	"youtube-backend/internal/domain/repositories"
@@ -12,34 +14,115 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	maxTags      = 30
+	maxTagLength = 40
+)
+
 type VideoService struct {
-	videoRepo    repositories.VideoRepository
-	jobRepo      repositories.JobRepository
-	jobPublisher JobPublisher
+	videoRepo      repositories.VideoRepository
+	jobRepo        repositories.JobRepository
+	contentKeyRepo repositories.ContentKeyRepository
+	playlistRepo   repositories.PlaylistRepository
+	commentRepo    repositories.CommentRepository
+	reactionRepo   repositories.ReactionRepository
+	viewStatsRepo  repositories.ViewStatsRepository
+	historyRepo    repositories.WatchHistoryRepository
+	channelRepo    repositories.ChannelRepository
+	jobPublisher   JobPublisher
+	mediaStore     MediaStore
+	quotaService   *QuotaService
+	keyRotation    int // segments per content key of encrypted videos
 }
 
 type JobPublisher interface {
+	PublishJob(ctx context.Context, job *entities.Job) error
 	PublishTranscodeJob(ctx context.Context, videoID primitive.ObjectID, jobID primitive.ObjectID, quality string) error
 	PublishThumbnailJob(ctx context.Context, videoID primitive.ObjectID, jobID primitive.ObjectID) error
 }
 
-func NewVideoService(videoRepo repositories.VideoRepository, jobRepo repositories.JobRepository, jobPublisher JobPublisher) *VideoService {
+// MediaStore lists and removes the stored objects of videos
+type MediaStore interface {
+	ListFileNames(ctx context.Context, prefix string) ([]string, error)
+	ListThumbnailNames(ctx context.Context, prefix string) ([]string, error)
+	DeleteFile(ctx context.Context, objectName string) error
+	DeleteThumbnail(ctx context.Context, objectName string) error
+}
+
+func NewVideoService(videoRepo repositories.VideoRepository, jobRepo repositories.JobRepository, contentKeyRepo repositories.ContentKeyRepository, playlistRepo repositories.PlaylistRepository, commentRepo repositories.CommentRepository, reactionRepo repositories.ReactionRepository, viewStatsRepo repositories.ViewStatsRepository, historyRepo repositories.WatchHistoryRepository, channelRepo repositories.ChannelRepository, jobPublisher JobPublisher, mediaStore MediaStore, quotaService *QuotaService, keyRotation int) *VideoService {
 	return &VideoService{
-		videoRepo:    videoRepo,
-		jobRepo:      jobRepo,
-		jobPublisher: jobPublisher,
+		videoRepo:      videoRepo,
+		jobRepo:        jobRepo,
+		contentKeyRepo: contentKeyRepo,
+		playlistRepo:   playlistRepo,
+		commentRepo:    commentRepo,
+		reactionRepo:   reactionRepo,
+		viewStatsRepo:  viewStatsRepo,
+		historyRepo:    historyRepo,
+		channelRepo:    channelRepo,
+		jobPublisher:   jobPublisher,
+		mediaStore:     mediaStore,
+		quotaService:   quotaService,
+		keyRotation:    keyRotation,
 	}
 }
 
+// CreateVideoInput holds the metadata of a new video
+type CreateVideoInput struct {
+	Title            string
+	Description      string
+	UploadedBy       string
+	ChannelID        *primitive.ObjectID // defaults to the uploader's first channel
+	OriginalFilename string
+	Size             int64
+	Tags             []string
+	Category         string              // optional slug of one of entities.Categories
+	Visibility       entities.Visibility // defaults to public
+	PublishAt        *time.Time          // optional scheduled release
+	Encrypted        bool                // encrypt HLS segments and disable progressive streams
+}
+
 // CreateVideo creates a new video and schedules processing jobs
-func (s *VideoService) CreateVideo(ctx context.Context, title, description, uploadedBy, originalFilename string, size int64) (*entities.Video, error) {
+func (s *VideoService) CreateVideo(ctx context.Context, input CreateVideoInput) (*entities.Video, error) {
+	if _, err := authorizeUpload(ctx); err != nil {
+		return nil, err
+	}
+
 	// Validate input
-	if err := s.validateVideoInput(title, originalFilename, size); err != nil {
+	if err := s.validateVideoInput(input.Title, input.OriginalFilename, input.Size); err != nil {
+		return nil, err
+	}
+	if input.Visibility != "" && !input.Visibility.IsValid() {
+		return nil, fmt.Errorf("%w: unknown visibility %q", ErrInvalidInput, input.Visibility)
+	}
+	if input.Category != "" && !entities.IsValidCategory(input.Category) {
+		return nil, fmt.Errorf("%w: unknown category %q", ErrInvalidInput, input.Category)
+	}
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	channelID, err := s.uploadChannel(ctx, input.UploadedBy, input.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the uploader's quota
+	if err := s.quotaService.CheckUpload(ctx, input.UploadedBy, input.Size); err != nil {
 		return nil, err
 	}
 
 	// Create video entity
-	video := entities.NewVideo(title, description, uploadedBy, originalFilename, size)
+	video := entities.NewVideo(input.Title, input.Description, input.UploadedBy, input.OriginalFilename, input.Size)
+	video.Tags = tags
+	video.Category = input.Category
+	video.ChannelID = channelID
+	if input.Visibility != "" {
+		video.Visibility = input.Visibility
+	}
+	video.PublishAt = input.PublishAt
+	video.Encrypted = input.Encrypted
 
 	// Save to repository
 	if err := s.videoRepo.Create(ctx, video); err != nil {
@@ -49,9 +132,18 @@ func (s *VideoService) CreateVideo(ctx context.Context, title, description, uplo
 	return video, nil
 }
 
-// GetVideo retrieves a video by ID
+// GetVideo retrieves a video by ID if the caller may watch it
 func (s *VideoService) GetVideo(ctx context.Context, id primitive.ObjectID) (*entities.Video, error) {
-	return s.videoRepo.GetByID(ctx, id)
+	video, err := s.videoRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	return video, nil
 }
 
 // UpdateVideoStatus updates the video status
@@ -87,49 +179,562 @@ func (s *VideoService) AddVideoThumbnail(ctx context.Context, videoID primitive.
 	return s.videoRepo.Update(ctx, video)
 }
 
-// ListVideos retrieves a paginated list of videos
-func (s *VideoService) ListVideos(ctx context.Context, limit, offset int) ([]*entities.Video, error) {
-	return s.videoRepo.List(ctx, limit, offset)
+// VideoQuery holds the search text and filters of a video listing
+type VideoQuery struct {
+	Text           string
+	Status         entities.VideoStatus
+	UploadedBy     string
+	MinDuration    float64 // in seconds
+	MaxDuration    float64 // in seconds
+	UploadedAfter  *time.Time
+	UploadedBefore *time.Time
+	Quality        string
+	Tag            string
+	Category       string
+	ChannelID      *primitive.ObjectID
 }
 
-// SearchVideos searches for videos by title or description
-func (s *VideoService) SearchVideos(ctx context.Context, query string, limit, offset int) ([]*entities.Video, error) {
-	return s.videoRepo.Search(ctx, query, limit, offset)
+// ListVideos retrieves a page of the videos the caller may discover, continuing after the cursor.
+// With search text the results are ranked by relevance, otherwise newest first.
+func (s *VideoService) ListVideos(ctx context.Context, query VideoQuery, limit int, cursor string) (*VideoPage, error) {
+	filter, err := s.videoFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var videos []*entities.Video
+	var next *repositories.PageCursor
+	if filter.Text != "" {
+		videos, next, err = s.videoRepo.Search(ctx, filter, limit, after)
+	} else {
+		videos, next, err = s.videoRepo.List(ctx, filter, limit, after)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.videoRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoPage{Videos: videos, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// videoFilter validates a query and restricts it to the videos the caller may discover
+func (s *VideoService) videoFilter(ctx context.Context, query VideoQuery) (repositories.VideoFilter, error) {
+	switch query.Status {
+	case "", entities.VideoStatusUploaded, entities.VideoStatusProcessing, entities.VideoStatusReady, entities.VideoStatusFailed:
+	default:
+		return repositories.VideoFilter{}, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, query.Status)
+	}
+	if query.MinDuration < 0 || query.MaxDuration < 0 || (query.MaxDuration > 0 && query.MinDuration > query.MaxDuration) {
+		return repositories.VideoFilter{}, fmt.Errorf("%w: invalid duration range", ErrInvalidInput)
+	}
+	if query.Category != "" && !entities.IsValidCategory(query.Category) {
+		return repositories.VideoFilter{}, fmt.Errorf("%w: unknown category %q", ErrInvalidInput, query.Category)
+	}
+
+	filter := listingFilter(ctx)
+	filter.Text = strings.TrimSpace(query.Text)
+	filter.Status = query.Status
+	filter.UploadedBy = query.UploadedBy
+	filter.MinDuration = query.MinDuration
+	filter.MaxDuration = query.MaxDuration
+	filter.UploadedAfter = query.UploadedAfter
+	filter.UploadedBefore = query.UploadedBefore
+	filter.Quality = query.Quality
+	filter.Tag = normalizeTag(query.Tag)
+	filter.Category = query.Category
+	if query.ChannelID != nil {
+		filter.ChannelIDs = []primitive.ObjectID{*query.ChannelID}
+	}
+	return filter, nil
 }
 
 // ScheduleProcessingJobs creates processing jobs for a video and publishes them to the queue
 func (s *VideoService) ScheduleProcessingJobs(ctx context.Context, videoID primitive.ObjectID) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
 	// Update video status to processing
-	if err := s.UpdateVideoStatus(ctx, videoID, entities.VideoStatusProcessing); err != nil {
+	video.UpdateStatus(entities.VideoStatusProcessing)
+	if err := s.videoRepo.Update(ctx, video); err != nil {
 		return fmt.Errorf("failed to update video status: %w", err)
 	}
 
+	return s.scheduleJobs(ctx, video)
+}
+
+// CheckSourceReplacement verifies that a video's source can be replaced by a file of the given size
+func (s *VideoService) CheckSourceReplacement(ctx context.Context, videoID primitive.ObjectID, filename string, size int64) (*entities.Video, error) {
+	video, err := s.getVideoForChange(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateVideoInput(video.Title, filename, size); err != nil {
+		return nil, err
+	}
+
+	if video.Status == entities.VideoStatusProcessing || video.IsReprocessing() {
+		return nil, ErrVideoBusy
+	}
+
+	if err := s.quotaService.CheckUpload(ctx, video.UploadedBy, size); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// ReplaceSource points a video at a newly uploaded source file, keeping the previous one as a revision,
+// and re-runs the processing pipeline. Current renditions keep serving until the new ones are complete.
+func (s *VideoService) ReplaceSource(ctx context.Context, videoID primitive.ObjectID, filename string, size int64) (*entities.Video, error) {
+	video, err := s.CheckSourceReplacement(ctx, videoID, filename, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// A video that never became ready has nothing to keep serving, so process it from scratch
+	reprocess := video.IsReady()
+
+	video.ReplaceSource(filename, size)
+	if !reprocess {
+		video.PendingRevision = 0
+		video.Formats = []entities.VideoFormat{}
+		video.Thumbnails = []string{}
+		video.UpdateStatus(entities.VideoStatusProcessing)
+	}
+
+	if err := s.videoRepo.Update(ctx, video); err != nil {
+		return nil, fmt.Errorf("failed to update video: %w", err)
+	}
+
+	if err := s.scheduleJobs(ctx, video); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// ReprocessVideo re-runs the processing pipeline for the video's current source. A ready video keeps its
+// live renditions until the new ones are promoted, and a failed source replacement is retried without
+// touching them. Only a video that never became ready is processed from scratch.
+func (s *VideoService) ReprocessVideo(ctx context.Context, videoID primitive.ObjectID) (*entities.Video, error) {
+	video, err := s.getVideoForChange(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if video.Status == entities.VideoStatusProcessing || video.IsReprocessing() {
+		return nil, ErrVideoBusy
+	}
+
+	switch {
+	case video.PendingRevision > 0:
+		if err := s.deleteRevisionJobs(ctx, video); err != nil {
+			return nil, err
+		}
+		video.PendingFormats = []entities.VideoFormat{}
+		video.PendingThumbnails = []string{}
+		video.PendingError = ""
+		video.UpdatedAt = time.Now()
+	case video.IsReady():
+		video.StartReprocessing()
+	default:
+		if err := s.deleteRevisionJobs(ctx, video); err != nil {
+			return nil, err
+		}
+		video.Formats = []entities.VideoFormat{}
+		video.Thumbnails = []string{}
+		video.ThumbnailsSize = 0
+		if video.CustomThumbnail != nil {
+			// The custom thumbnail is kept, only the generated ones are redone
+			video.ThumbnailsSize = video.CustomThumbnail.Size
+		}
+		video.UpdateStatus(entities.VideoStatusProcessing)
+	}
+
+	if err := s.videoRepo.Update(ctx, video); err != nil {
+		return nil, fmt.Errorf("failed to update video: %w", err)
+	}
+
+	if err := s.scheduleJobs(ctx, video); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// deleteRevisionJobs drops the jobs of the revision being redone so its finished jobs don't count towards
+// its completion
+func (s *VideoService) deleteRevisionJobs(ctx context.Context, video *entities.Video) error {
+	jobs, err := s.jobRepo.GetByVideoID(ctx, video.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if job.Revision != video.Revision {
+			continue
+		}
+		if err := s.jobRepo.Delete(ctx, job.ID); err != nil {
+			return fmt.Errorf("failed to delete job: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateVideoInput holds the metadata fields to change; nil fields are left as they are
+type UpdateVideoInput struct {
+	Title       *string
+	Description *string
+	Tags        []string            // replaces all tags when non-nil
+	Category    *string             // an empty category removes the video from its category
+	ChannelID   *primitive.ObjectID // moves the video to another channel of its uploader
+
+	Visibility *entities.Visibility
+	PublishAt  *time.Time // schedules the release; a zero time removes the schedule
+}
+
+// UpdateVideo changes the title, description, tags, category, channel or visibility of a video
+func (s *VideoService) UpdateVideo(ctx context.Context, videoID primitive.ObjectID, input UpdateVideoInput) (*entities.Video, error) {
+	video, err := s.getVideoForChange(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		if strings.TrimSpace(*input.Title) == "" {
+			return nil, fmt.Errorf("%w: title cannot be empty", ErrInvalidInput)
+		}
+		video.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Description != nil {
+		video.Description = *input.Description
+	}
+	if input.Tags != nil {
+		tags, err := normalizeTags(input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		video.Tags = tags
+	}
+	if input.Category != nil {
+		if *input.Category != "" && !entities.IsValidCategory(*input.Category) {
+			return nil, fmt.Errorf("%w: unknown category %q", ErrInvalidInput, *input.Category)
+		}
+		video.Category = *input.Category
+	}
+
+	if input.ChannelID != nil {
+		if err := s.checkChannelOwner(ctx, *input.ChannelID, video.UploadedBy); err != nil {
+			return nil, err
+		}
+	}
+	if input.Visibility != nil && !input.Visibility.IsValid() {
+		return nil, fmt.Errorf("%w: unknown visibility %q", ErrInvalidInput, *input.Visibility)
+	}
+
+	if err := s.videoRepo.UpdateMetadata(ctx, video.ID, video.Title, video.Description, video.Category, video.Tags); err != nil {
+		return nil, err
+	}
+	if input.ChannelID != nil {
+		if err := s.videoRepo.SetChannel(ctx, video.ID, *input.ChannelID); err != nil {
+			return nil, err
+		}
+		video.ChannelID = *input.ChannelID
+	}
+	if input.Visibility != nil || input.PublishAt != nil {
+		if input.Visibility != nil {
+			video.Visibility = *input.Visibility
+		}
+		if input.PublishAt != nil {
+			video.PublishAt = input.PublishAt
+			if input.PublishAt.IsZero() {
+				video.PublishAt = nil
+			}
+		}
+		if err := s.videoRepo.SetVisibility(ctx, video.ID, video.EffectiveVisibility(), video.PublishAt); err != nil {
+			return nil, err
+		}
+	}
+	video.UpdatedAt = time.Now()
+
+	return video, nil
+}
+
+// DeleteVideo moves a video into the trash. It disappears from listings and playback
+// but keeps its files until it is restored or purged.
+func (s *VideoService) DeleteVideo(ctx context.Context, videoID primitive.ObjectID) error {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return ErrVideoNotFound
+	}
+
+	if err := authorizeVideoChange(ctx, video); err != nil {
+		return err
+	}
+
+	if video.IsTrashed() {
+		return nil
+	}
+
+	now := time.Now()
+	return s.videoRepo.SetDeletedAt(ctx, video.ID, &now)
+}
+
+// RestoreVideo takes a video out of the trash
+func (s *VideoService) RestoreVideo(ctx context.Context, videoID primitive.ObjectID) (*entities.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+
+	if err := authorizeVideoChange(ctx, video); err != nil {
+		return nil, err
+	}
+
+	if !video.IsTrashed() {
+		return video, nil
+	}
+
+	if err := s.videoRepo.SetDeletedAt(ctx, video.ID, nil); err != nil {
+		return nil, err
+	}
+	video.DeletedAt = nil
+	video.UpdatedAt = time.Now()
+
+	return video, nil
+}
+
+// ListTrash lists a page of the caller's videos that are in the trash
+func (s *VideoService) ListTrash(ctx context.Context, limit int, cursor string) (*VideoPage, error) {
+	identity, err := requireIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.VideoFilter{Trashed: true, UploadedBy: identity.UserID()}
+	videos, next, err := s.videoRepo.List(ctx, filter, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.videoRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoPage{Videos: videos, NextCursor: encodeCursor(next), Total: total}, nil
+}
+
+// PurgeTrash permanently deletes videos that were trashed before the given time and returns how many were removed
+func (s *VideoService) PurgeTrash(ctx context.Context, trashedBefore time.Time, limit int) (int, error) {
+	videos, err := s.videoRepo.GetTrashedBefore(ctx, trashedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, video := range videos {
+		if err := s.purgeVideo(ctx, video); err != nil {
+			return purged, fmt.Errorf("failed to purge video %s: %w", video.ID.Hex(), err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purgeVideo removes a video with its jobs, content keys, playlist entries, comments, reactions, view statistics, watch history and every stored object.
+// The video document goes last, so a purge that fails partway is simply retried on the next run.
+func (s *VideoService) purgeVideo(ctx context.Context, video *entities.Video) error {
+	// Stop workers from picking up jobs that would write new objects
+	if _, err := s.jobRepo.CancelPendingByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.deleteStoredObjects(ctx, video); err != nil {
+		return err
+	}
+
+	if err := s.contentKeyRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.jobRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.playlistRepo.RemoveVideoFromAll(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.reactionRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.viewStatsRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	if err := s.historyRepo.DeleteByVideoID(ctx, video.ID); err != nil {
+		return err
+	}
+
+	return s.videoRepo.Delete(ctx, video.ID)
+}
+
+// uploadChannel picks the channel a new video is published under: the requested one, which must
+// belong to the uploader, or else the uploader's first channel. Uploaders without channels publish
+// without one.
+func (s *VideoService) uploadChannel(ctx context.Context, uploadedBy string, channelID *primitive.ObjectID) (primitive.ObjectID, error) {
+	if channelID != nil {
+		if err := s.checkChannelOwner(ctx, *channelID, uploadedBy); err != nil {
+			return primitive.NilObjectID, err
+		}
+		return *channelID, nil
+	}
+
+	channels, err := s.channelRepo.ListByOwner(ctx, uploadedBy)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if len(channels) == 0 {
+		return primitive.NilObjectID, nil
+	}
+	return channels[0].ID, nil
+}
+
+// checkChannelOwner checks that a channel exists and belongs to the user
+func (s *VideoService) checkChannelOwner(ctx context.Context, channelID primitive.ObjectID, owner string) error {
+	channel, err := s.channelRepo.GetByID(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	if channel == nil {
+		return ErrChannelNotFound
+	}
+	if channel.Owner != owner {
+		return fmt.Errorf("%w: channel belongs to another user", ErrForbidden)
+	}
+	return nil
+}
+
+// getVideoForChange loads a live video and checks that the caller may modify it
+func (s *VideoService) getVideoForChange(ctx context.Context, videoID primitive.ObjectID) (*entities.Video, error) {
+	return loadVideoForChange(ctx, s.videoRepo, videoID)
+}
+
+// loadVideoForChange loads a live video and checks that the caller may modify it. Trashed videos
+// can only be restored or purged, so to every other change they don't exist.
+func loadVideoForChange(ctx context.Context, videoRepo repositories.VideoRepository, videoID primitive.ObjectID) (*entities.Video, error) {
+	video, err := videoRepo.GetByID(ctx, videoID)
+	if err != nil || video.IsTrashed() {
+		return nil, ErrVideoNotFound
+	}
+
+	if err := authorizeVideoChange(ctx, video); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// deleteStoredObjects removes originals, revisions, renditions, HLS packages and thumbnails of a video.
+// Every object name starts with the video ID, which also catches files of unfinished jobs.
+func (s *VideoService) deleteStoredObjects(ctx context.Context, video *entities.Video) error {
+	id := video.ID.Hex()
+
+	for _, prefix := range []string{"videos/original/" + id, "videos/processed/" + id, "videos/hls/" + id, "videos/captions/" + id} {
+		names, err := s.mediaStore.ListFileNames(ctx, prefix)
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, name := range names {
+			if err := s.mediaStore.DeleteFile(ctx, name); err != nil {
+				return fmt.Errorf("failed to delete object %s: %w", name, err)
+			}
+		}
+	}
+
+	thumbnails, err := s.mediaStore.ListThumbnailNames(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to list thumbnails: %w", err)
+	}
+	for _, name := range thumbnails {
+		if err := s.mediaStore.DeleteThumbnail(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete thumbnail %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// scheduleJobs creates and publishes the thumbnail and transcode jobs for the video's current source.
+// Jobs of a pending revision write into the pending renditions instead of the live ones.
+func (s *VideoService) scheduleJobs(ctx context.Context, video *entities.Video) error {
+	// Resolve limits so the worker can enforce the maximum duration once it is known
+	limits, err := s.quotaService.GetLimits(ctx, video.UploadedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get upload limits: %w", err)
+	}
+
 	// Schedule thumbnail generation job
-	thumbnailJob := entities.NewJob(videoID, entities.JobTypeThumbnail, map[string]any{
-		"video_id": videoID.Hex(),
+	thumbnailJob := entities.NewJob(video.ID, entities.JobTypeThumbnail, map[string]any{
+		"video_id":      video.ID.Hex(),
+		"max_duration":  limits.MaxDuration,
+		"revision":      video.Revision,
+		"pending":       video.PendingRevision > 0,
+		"source_object": video.OriginalObjectName(),
 	})
+	thumbnailJob.Revision = video.Revision
 	if err := s.jobRepo.Create(ctx, thumbnailJob); err != nil {
 		return fmt.Errorf("failed to create thumbnail job: %w", err)
 	}
 
 	// Publish thumbnail job to queue
-	if err := s.jobPublisher.PublishThumbnailJob(ctx, videoID, thumbnailJob.ID); err != nil {
+	if err := s.jobPublisher.PublishJob(ctx, thumbnailJob); err != nil {
 		return fmt.Errorf("failed to publish thumbnail job: %w", err)
 	}
 
 	// Schedule transcoding jobs for different qualities
 	qualities := []string{"480p", "720p", "1080p"}
 	for _, quality := range qualities {
-		transcodeJob := entities.NewJob(videoID, entities.JobTypeTranscode, map[string]any{
-			"video_id": videoID.Hex(),
-			"quality":  quality,
+		transcodeJob := entities.NewJob(video.ID, entities.JobTypeTranscode, map[string]any{
+			"video_id":      video.ID.Hex(),
+			"quality":       quality,
+			"max_duration":  limits.MaxDuration,
+			"revision":      video.Revision,
+			"pending":       video.PendingRevision > 0,
+			"source_object": video.OriginalObjectName(),
+			"encrypt":       video.Encrypted,
+			"key_rotation":  s.keyRotation,
 		})
+		transcodeJob.Revision = video.Revision
 		if err := s.jobRepo.Create(ctx, transcodeJob); err != nil {
 			return fmt.Errorf("failed to create transcode job for %s: %w", quality, err)
 		}
 
 		// Publish transcode job to queue
-		if err := s.jobPublisher.PublishTranscodeJob(ctx, videoID, transcodeJob.ID, quality); err != nil {
+		if err := s.jobPublisher.PublishJob(ctx, transcodeJob); err != nil {
 			return fmt.Errorf("failed to publish transcode job for %s: %w", quality, err)
 		}
 	}
@@ -161,15 +766,38 @@ func (s *VideoService) validateVideoInput(title, filename string, size int64) er
 		return fmt.Errorf("unsupported file format: %s", ext)
 	}
 
-	// Check file size (max 1GB for example)
-	maxSize := int64(1024 * 1024 * 1024) // 1GB
-	if size > maxSize {
-		return fmt.Errorf("file size too large: %d bytes (max: %d bytes)", size, maxSize)
-	}
-
 	if size <= 0 {
 		return fmt.Errorf("invalid file size: %d", size)
 	}
 
 	return nil
 }
+
+// normalizeTags lowercases tags, strips a leading '#', collapses inner whitespace
+// and drops empty and duplicate entries, so "#Pasta  Recipe" and "pasta recipe" are one tag
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidInput, tag, maxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, fmt.Errorf("%w: a video can have at most %d tags", ErrInvalidInput, maxTags)
+	}
+	return normalized, nil
+}
+
+// normalizeTag brings a single tag into its canonical form
+func normalizeTag(tag string) string {
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tooMany := make([]string, maxTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag" + strings.Repeat("x", i)
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "nil", tags: nil, want: []string{}},
+		{name: "lowercased and trimmed", tags: []string{"  Go ", "DevOps"}, want: []string{"go", "devops"}},
+		{name: "hashes removed", tags: []string{"#golang", "##video"}, want: []string{"golang", "video"}},
+		{name: "inner whitespace collapsed", tags: []string{"live   coding\tsession"}, want: []string{"live coding session"}},
+		{name: "duplicates after normalizing dropped", tags: []string{"Go", "#go", " GO "}, want: []string{"go"}},
+		{name: "empty tags dropped", tags: []string{"", "  ", "#", "go"}, want: []string{"go"}},
+		{name: "order kept", tags: []string{"b", "a", "c"}, want: []string{"b", "a", "c"}},
+		{name: "longest allowed tag", tags: []string{strings.Repeat("ä", maxTagLength)}, want: []string{strings.Repeat("ä", maxTagLength)}},
+		{name: "tag too long", tags: []string{strings.Repeat("a", maxTagLength+1)}, wantErr: true},
+		{name: "most tags allowed", tags: tooMany[:maxTags], want: tooMany[:maxTags]},
+		{name: "too many tags", tags: tooMany, wantErr: true},
+		{name: "duplicates do not count towards the limit", tags: append(tooMany[:maxTags:maxTags], tooMany[0]), want: tooMany[:maxTags]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.tags)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("normalizeTags() error = %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeTags() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxPlaybackRate is the fastest playback speed whose progress still counts as watched
+	maxPlaybackRate = 2
+	// positionTolerance absorbs clock skew and buffering between a player's position and the server clock, in seconds
+	positionTolerance = 5
+	// maxAnalyticsDays bounds the date range of an analytics request
+	maxAnalyticsDays = 366
+)
+
+// ViewTracker keeps the short-lived state of playback: recent viewers and open view sessions
+type ViewTracker interface {
+	// MarkViewed records that the viewer watched the video and reports whether they had not
+	// already done so within window
+	MarkViewed(ctx context.Context, videoID primitive.ObjectID, viewerKey string, window time.Duration) (bool, error)
+	SaveSession(ctx context.Context, session *entities.ViewSession, ttl time.Duration) error
+	// GetSession returns the view session, or nil if it does not exist or expired
+	GetSession(ctx context.Context, id string) (*entities.ViewSession, error)
+	DeleteSession(ctx context.Context, id string) error
+}
+
+// Viewer describes an anonymous caller well enough to deduplicate their views.
+// Signed-in callers are recognized by their account instead.
+type Viewer struct {
+	ClientIP  string
+	UserAgent string
+}
+
+// ViewStart is the outcome of a start event
+type ViewStart struct {
+	// ViewID identifies the view session in the heartbeat and end events that follow
+	ViewID string
+	// Counted reports whether the playback counted as a new view; repeated plays by the same viewer within the dedup window don't
+	Counted bool
+}
+
+// DailyViews is the playback of a video on one UTC day
+type DailyViews struct {
+	Day       time.Time
+	Views     int64
+	Plays     int64
+	WatchTime float64 // in seconds
+}
+
+// RetentionPoint is one bucket of the audience retention curve
+type RetentionPoint struct {
+	Start float64 // where the bucket begins, as a fraction of the video's duration
+	Plays int64   // plays that watched part of the bucket
+	Share float64 // Plays relative to all plays
+}
+
+// VideoAnalytics aggregates the playback of a video over a range of days
+type VideoAnalytics struct {
+	VideoID    primitive.ObjectID
+	TotalViews int64 // all-time view count
+	From, To   time.Time
+	Days       []DailyViews // every day of the range, oldest first
+	Views      int64
+	Plays      int64
+	WatchTime  float64
+	// AverageWatchTime is the watch time per play, in seconds
+	AverageWatchTime float64
+	Retention        []RetentionPoint
+}
+
+type ViewService struct {
+	videoRepo   repositories.VideoRepository
+	statsRepo   repositories.ViewStatsRepository
+	history     *HistoryService
+	tracker     ViewTracker
+	dedupWindow time.Duration
+	sessionTTL  time.Duration
+}
+
+func NewViewService(videoRepo repositories.VideoRepository, statsRepo repositories.ViewStatsRepository, history *HistoryService, tracker ViewTracker, dedupWindow, sessionTTL time.Duration) *ViewService {
+	return &ViewService{
+		videoRepo:   videoRepo,
+		statsRepo:   statsRepo,
+		history:     history,
+		tracker:     tracker,
+		dedupWindow: dedupWindow,
+		sessionTTL:  sessionTTL,
+	}
+}
+
+// StartView opens a view session for a video the caller may watch. The playback counts as a
+// view unless the same viewer already started one within the dedup window.
+func (s *ViewService) StartView(ctx context.Context, videoID primitive.ObjectID, viewer Viewer, position float64) (*ViewStart, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+	if err := authorizeVideoView(ctx, video); err != nil {
+		return nil, err
+	}
+
+	viewID, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate view ID: %w", err)
+	}
+
+	now := time.Now()
+	session := &entities.ViewSession{
+		ID:          viewID,
+		VideoID:     videoID,
+		Duration:    video.Duration,
+		Day:         now.UTC().Truncate(24 * time.Hour),
+		StartedAt:   now,
+		Position:    clampPosition(position, video.Duration),
+		LastEventAt: now,
+	}
+	if identity := IdentityFromContext(ctx); identity != nil && !identity.User.HistoryPaused {
+		session.UserID = identity.UserID()
+	}
+
+	delta := entities.ViewStatsDelta{Plays: 1}
+	if bucket := entities.RetentionBucket(session.Position, session.Duration); bucket >= 0 {
+		session.Buckets |= 1 << bucket
+		delta.Buckets = []int{bucket}
+	}
+
+	counted, err := s.tracker.MarkViewed(ctx, videoID, viewerKey(ctx, viewer), s.dedupWindow)
+	if err != nil {
+		return nil, err
+	}
+	if counted {
+		delta.Views = 1
+		if err := s.videoRepo.IncrementViews(ctx, videoID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.statsRepo.Record(ctx, videoID, session.Day, delta); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveProgress(ctx, session); err != nil {
+		return nil, err
+	}
+
+	if err := s.tracker.SaveSession(ctx, session, s.sessionTTL); err != nil {
+		return nil, err
+	}
+
+	return &ViewStart{ViewID: viewID, Counted: counted}, nil
+}
+
+// RecordProgress applies a heartbeat or, when ended is set, the end event of a view session
+func (s *ViewService) RecordProgress(ctx context.Context, videoID primitive.ObjectID, viewID string, position float64, ended bool) error {
+	session, err := s.tracker.GetSession(ctx, viewID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.VideoID != videoID {
+		return fmt.Errorf("%w: unknown or expired view; send a start event", ErrInvalidInput)
+	}
+
+	delta := advanceSession(session, position, time.Now())
+	if !delta.IsEmpty() {
+		if err := s.statsRepo.Record(ctx, videoID, session.Day, delta); err != nil {
+			return err
+		}
+	}
+
+	if err := s.saveProgress(ctx, session); err != nil {
+		return err
+	}
+
+	if ended {
+		return s.tracker.DeleteSession(ctx, viewID)
+	}
+	return s.tracker.SaveSession(ctx, session, s.sessionTTL)
+}
+
+// GetAnalytics aggregates the playback of a video over the UTC days from and to, inclusive.
+// Only the uploader, moderators and admins may see it.
+func (s *ViewService) GetAnalytics(ctx context.Context, videoID primitive.ObjectID, from, to time.Time) (*VideoAnalytics, error) {
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidInput)
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxAnalyticsDays {
+		return nil, fmt.Errorf("%w: date range is longer than %d days", ErrInvalidInput, maxAnalyticsDays)
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, ErrVideoNotFound
+	}
+	if err := authorizeOwnerOrModerator(ctx, video.UploadedBy); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.statsRepo.GetDaily(ctx, videoID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &VideoAnalytics{
+		VideoID:    videoID,
+		TotalViews: video.ViewCount,
+		From:       from,
+		To:         to,
+		Days:       make([]DailyViews, days),
+	}
+	for i := range analytics.Days {
+		analytics.Days[i].Day = from.AddDate(0, 0, i)
+	}
+
+	retention := make([]int64, entities.RetentionBuckets)
+	for _, day := range stats {
+		i := int(day.Day.UTC().Sub(from).Hours() / 24)
+		if i < 0 || i >= days {
+			continue
+		}
+		analytics.Days[i].Views = day.Views
+		analytics.Days[i].Plays = day.Plays
+		analytics.Days[i].WatchTime = day.WatchTime
+
+		analytics.Views += day.Views
+		analytics.Plays += day.Plays
+		analytics.WatchTime += day.WatchTime
+		for bucket := range retention {
+			retention[bucket] += day.RetentionAt(bucket)
+		}
+	}
+
+	if analytics.Plays > 0 {
+		analytics.AverageWatchTime = analytics.WatchTime / float64(analytics.Plays)
+	}
+
+	analytics.Retention = make([]RetentionPoint, entities.RetentionBuckets)
+	for bucket, plays := range retention {
+		point := RetentionPoint{Start: float64(bucket) / entities.RetentionBuckets, Plays: plays}
+		if analytics.Plays > 0 {
+			point.Share = float64(plays) / float64(analytics.Plays)
+		}
+		analytics.Retention[bucket] = point
+	}
+
+	return analytics, nil
+}
+
+// saveProgress stores the session's position in the viewer's watch history, if it is recorded
+func (s *ViewService) saveProgress(ctx context.Context, session *entities.ViewSession) error {
+	if session.UserID == "" {
+		return nil
+	}
+	return s.history.RecordProgress(ctx, session.UserID, session.VideoID, session.Position, session.Duration, session.StartedAt)
+}
+
+// advanceSession moves a view session to the reported position and returns what that adds to the
+// statistics. Only forward progress at a plausible playback speed counts as watched, so seeks add
+// neither watch time nor retention for the skipped range.
+func advanceSession(session *entities.ViewSession, position float64, now time.Time) entities.ViewStatsDelta {
+	position = clampPosition(position, session.Duration)
+	elapsed := now.Sub(session.LastEventAt).Seconds()
+	progress := position - session.Position
+
+	var delta entities.ViewStatsDelta
+	if progress > 0 && progress <= elapsed*maxPlaybackRate+positionTolerance {
+		delta.WatchTime = progress
+
+		first := entities.RetentionBucket(session.Position, session.Duration)
+		last := entities.RetentionBucket(position, session.Duration)
+		for bucket := first; first >= 0 && bucket <= last; bucket++ {
+			if session.Buckets&(1<<bucket) == 0 {
+				session.Buckets |= 1 << bucket
+				delta.Buckets = append(delta.Buckets, bucket)
+			}
+		}
+	}
+
+	session.Position = position
+	session.LastEventAt = now
+	return delta
+}
+
+// clampPosition limits a reported position to the video's duration, when known
+func clampPosition(position, duration float64) float64 {
+	if position < 0 {
+		return 0
+	}
+	if duration > 0 && position > duration {
+		return duration
+	}
+	return position
+}
+
+// viewerKey identifies the viewer for view deduplication without storing client addresses.
+// Anonymous viewers are told apart by what the server sees of them, never by a value the client
+// chooses, so clearing or rotating cookies does not count a replay as a new view.
+func viewerKey(ctx context.Context, viewer Viewer) string {
+	if identity := IdentityFromContext(ctx); identity != nil {
+		return "user:" + identity.UserID()
+	}
+	return "client:" + hashToken(viewer.ClientIP+"|"+viewer.UserAgent)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAdvanceSession(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		duration      float64 // 200 seconds gives 10 second retention buckets
+		position      float64
+		buckets       uint64
+		elapsed       time.Duration
+		report        float64
+		wantWatchTime float64
+		wantBuckets   []int
+		wantPosition  float64
+	}{
+		{name: "normal playback", duration: 200, position: 0, elapsed: 10 * time.Second, report: 10, wantWatchTime: 10, wantBuckets: []int{0, 1}, wantPosition: 10},
+		{name: "double speed counts", duration: 200, position: 0, elapsed: 20 * time.Second, report: 40, wantWatchTime: 40, wantBuckets: []int{0, 1, 2, 3, 4}, wantPosition: 40},
+		{name: "tolerance for buffering", duration: 200, position: 0, elapsed: 5 * time.Second, report: 14, wantWatchTime: 14, wantBuckets: []int{0, 1}, wantPosition: 14},
+		{name: "seeking forward adds nothing", duration: 200, position: 0, elapsed: 10 * time.Second, report: 100, wantPosition: 100},
+		{name: "seeking back adds nothing", duration: 200, position: 100, elapsed: 10 * time.Second, report: 50, wantPosition: 50},
+		{name: "paused adds nothing", duration: 200, position: 30, elapsed: 30 * time.Second, report: 30, wantPosition: 30},
+		{name: "watched buckets are not repeated", duration: 200, position: 5, buckets: 1<<0 | 1<<1, elapsed: 10 * time.Second, report: 15, wantWatchTime: 10, wantPosition: 15},
+		{name: "partly watched range adds new buckets", duration: 200, position: 15, buckets: 1<<0 | 1<<1, elapsed: 10 * time.Second, report: 25, wantWatchTime: 10, wantBuckets: []int{2}, wantPosition: 25},
+		{name: "clamped to the duration", duration: 200, position: 195, elapsed: 10 * time.Second, report: 250, wantWatchTime: 5, wantBuckets: []int{19}, wantPosition: 200},
+		{name: "negative position clamped to zero", duration: 200, position: 0, elapsed: 10 * time.Second, report: -5, wantPosition: 0},
+		{name: "unknown duration counts watch time only", duration: 0, position: 0, elapsed: 10 * time.Second, report: 10, wantWatchTime: 10, wantPosition: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &entities.ViewSession{Duration: tt.duration, Position: tt.position, Buckets: tt.buckets, LastEventAt: start}
+			now := start.Add(tt.elapsed)
+
+			delta := advanceSession(session, tt.report, now)
+
+			if delta.WatchTime != tt.wantWatchTime {
+				t.Errorf("WatchTime = %v, want %v", delta.WatchTime, tt.wantWatchTime)
+			}
+			if !reflect.DeepEqual(delta.Buckets, tt.wantBuckets) {
+				t.Errorf("Buckets = %v, want %v", delta.Buckets, tt.wantBuckets)
+			}
+			if session.Position != tt.wantPosition {
+				t.Errorf("session.Position = %v, want %v", session.Position, tt.wantPosition)
+			}
+			if !session.LastEventAt.Equal(now) {
+				t.Errorf("session.LastEventAt = %v, want %v", session.LastEventAt, now)
+			}
+			for _, bucket := range tt.wantBuckets {
+				if session.Buckets&(1<<bucket) == 0 {
+					t.Errorf("session.Buckets = %b, want bucket %d set", session.Buckets, bucket)
+				}
+			}
+		})
+	}
+}
+
+func TestViewerKey(t *testing.T) {
+	anonymous := context.Background()
+	signedIn := WithIdentity(anonymous, &Identity{User: &entities.User{ID: primitive.NewObjectID()}})
+	viewer := Viewer{ClientIP: "203.0.113.7", UserAgent: "test-player"}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		other    Viewer
+		wantSame bool
+	}{
+		{name: "same client", ctx: anonymous, other: viewer, wantSame: true},
+		{name: "other address", ctx: anonymous, other: Viewer{ClientIP: "198.51.100.1", UserAgent: "test-player"}},
+		{name: "other user agent", ctx: anonymous, other: Viewer{ClientIP: "203.0.113.7", UserAgent: "other-player"}},
+		{name: "signed in user on another address", ctx: signedIn, other: Viewer{ClientIP: "198.51.100.1", UserAgent: "other-player"}, wantSame: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			same := viewerKey(tt.ctx, viewer) == viewerKey(tt.ctx, tt.other)
+			if same != tt.wantSame {
+				t.Errorf("viewerKey() same = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"fmt"
+)
+
+// ContentKeyWrapper unwraps HLS content keys stored by the worker.
+// Keys are sealed with AES-256-GCM under a master key derived from the shared secret.
+type ContentKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+func NewContentKeyWrapper(secret string) (*ContentKeyWrapper, error) {
+	master := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(master[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key cipher: %w", err)
+	}
+
+	return &ContentKeyWrapper{aead: aead}, nil
+}
+
+// Unwrap decrypts a wrapped content key
+func (w *ContentKeyWrapper) Unwrap(wrapped, nonce []byte) ([]byte, error) {
+	if len(nonce) != w.aead.NonceSize() {
+		return nil, fmt.Errorf("invalid content key nonce")
+	}
+
+	key, err := w.aead.Open(nil, nonce, wrapped, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap content key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const issuer = "youtube-backend"
+
+// JWTManager issues and verifies HMAC-signed access tokens
+type JWTManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewJWTManager(secret string, ttl time.Duration) *JWTManager {
+	return &JWTManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// IssueAccessToken creates a signed token for the user
+func (m *JWTManager) IssueAccessToken(user *entities.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   user.ID.Hex(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		ID:        primitive.NewObjectID().Hex(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// ParseAccessToken verifies a token and returns the ID of its user
+func (m *JWTManager) ParseAccessToken(tokenString string) (primitive.ObjectID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("invalid token subject: %w", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPlaybackSignerRoundTrip(t *testing.T) {
+	signer := NewPlaybackSigner("playback-secret")
+
+	tests := []struct {
+		name   string
+		claims services.PlaybackClaims
+	}{
+		{name: "unbound", claims: services.PlaybackClaims{VideoID: primitive.NewObjectID(), ExpiresAt: time.Unix(1700000000, 0)}},
+		{name: "bound to address and session", claims: services.PlaybackClaims{VideoID: primitive.NewObjectID(), ExpiresAt: time.Unix(1700000000, 0), ClientIP: "203.0.113.7", SessionID: "session-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := signer.Sign(tt.claims)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			claims, err := signer.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.VideoID != tt.claims.VideoID || !claims.ExpiresAt.Equal(tt.claims.ExpiresAt) ||
+				claims.ClientIP != tt.claims.ClientIP || claims.SessionID != tt.claims.SessionID {
+				t.Errorf("Verify() = %+v, want %+v", *claims, tt.claims)
+			}
+		})
+	}
+}
+
+func TestPlaybackSignerRejectsForgedTokens(t *testing.T) {
+	signer := NewPlaybackSigner("playback-secret")
+	token, err := signer.Sign(services.PlaybackClaims{VideoID: primitive.NewObjectID(), ExpiresAt: time.Unix(1700000000, 0), ClientIP: "203.0.113.7"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+
+	// Claims signed for another address, to be paired with the original signature
+	other, err := signer.Sign(services.PlaybackClaims{VideoID: primitive.NewObjectID(), ExpiresAt: time.Unix(1700000000, 0), ClientIP: "198.51.100.1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	// Change the first signature character, which unlike the last carries no unused bits
+	tampered := "A" + signature[1:]
+	if signature[0] == 'A' {
+		tampered = "B" + signature[1:]
+	}
+
+	otherSecret, err := NewPlaybackSigner("other-secret").Sign(services.PlaybackClaims{VideoID: primitive.NewObjectID(), ExpiresAt: time.Unix(1700000000, 0)})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "no signature", token: payload},
+		{name: "tampered signature", token: payload + "." + tampered},
+		{name: "swapped claims", token: otherPayload + "." + signature},
+		{name: "signed with another secret", token: otherSecret},
+		{name: "signature not base64", token: payload + ".!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token); err == nil {
+				t.Errorf("Verify(%q) succeeded, want an error", tt.token)
+			}
+		})
+	}
+}
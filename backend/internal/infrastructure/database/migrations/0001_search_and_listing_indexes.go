@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createSearchAndListingIndexes creates the text index for search and the indexes cursor pagination walks
+func createSearchAndListingIndexes(ctx context.Context, db *mongo.Database) error {
+	// Weighted text index for search: title matches rank above tags, tags above description
+	_, err := db.Collection("videos").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "tags", Value: "text"},
+				{Key: "description", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("videos_text").
+				SetWeights(bson.M{"title": 10, "tags": 5, "description": 1}).
+				SetDefaultLanguage("english"),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("videos_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create videos indexes: %w", err)
+	}
+
+	_, err = db.Collection("jobs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("jobs_video_id_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("jobs_status_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create jobs indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createLookupIndexes indexes the fields videos, keys and users are looked up by,
+// and makes usernames and emails unique so concurrent registrations cannot collide
+func createLookupIndexes(ctx context.Context, db *mongo.Database) error {
+	// Jobs by video_id and by status, created_at are served by the indexes of migration 1
+	_, err := db.Collection("videos").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uploaded_by", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("videos_uploaded_by_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().SetName("videos_status"),
+		},
+		{
+			Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().SetName("videos_deleted_at").SetSparse(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create videos indexes: %w", err)
+	}
+
+	_, err = db.Collection("content_keys").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "video_id", Value: 1}},
+		Options: options.Index().SetName("content_keys_video_id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create content keys indexes: %w", err)
+	}
+
+	_, err = db.Collection("users").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}},
+			Options: options.Index().SetName("users_username").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("users_email").SetUnique(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create users indexes: %w", err)
+	}
+
+	return nil
+}
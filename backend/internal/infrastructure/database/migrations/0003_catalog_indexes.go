@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createCatalogIndexes serves browsing by tag and by category in listing order
+func createCatalogIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("videos").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tags", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("videos_tags_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "category", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("videos_category_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create videos catalog indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createPlaylistIndexes serves playlist listings and finding the playlists that contain a video
+func createPlaylistIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("playlists").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("playlists_owner_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "visibility", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("playlists_visibility_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "video_ids", Value: 1}},
+			Options: options.Index().SetName("playlists_video_ids"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create playlists indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createCommentIndexes serves the newest and top orderings of a video's threads and the replies of a thread
+func createCommentIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("comments").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("comments_video_id_parent_id_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "like_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("comments_video_id_parent_id_like_count_created_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("comments_parent_id_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comments indexes: %w", err)
+	}
+
+	return nil
+}
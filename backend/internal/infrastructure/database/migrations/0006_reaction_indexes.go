@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createReactionIndexes allows one reaction per user and video, and serves counting a video's reactions
+func createReactionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("reactions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("reactions_video_id_user_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "type", Value: 1}},
+			Options: options.Index().SetName("reactions_video_id_type"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reactions indexes: %w", err)
+	}
+
+	return nil
+}
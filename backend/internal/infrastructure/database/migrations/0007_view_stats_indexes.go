@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createViewStatsIndexes keeps one statistics document per video and day, which view events upsert into
+func createViewStatsIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("video_view_stats").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetName("video_view_stats_video_id_day").SetUnique(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create video_view_stats indexes: %w", err)
+	}
+
+	return nil
+}
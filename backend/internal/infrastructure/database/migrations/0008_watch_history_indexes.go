@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createWatchHistoryIndexes keeps one entry per user and video and serves history listings and purges
+func createWatchHistoryIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("watch_history").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "video_id", Value: 1}},
+			Options: options.Index().SetName("watch_history_user_id_video_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "watched_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("watch_history_user_id_watched_at_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}},
+			Options: options.Index().SetName("watch_history_video_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create watch_history indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createChannelIndexes keeps channel handles and subscriptions unique and serves channel,
+// subscription and subscription feed listings
+func createChannelIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("channels").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "handle", Value: 1}},
+			Options: options.Index().SetName("channels_handle").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "owner", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("channels_owner_created_at"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create channels indexes: %w", err)
+	}
+
+	_, err = db.Collection("subscriptions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "channel_id", Value: 1}},
+			Options: options.Index().SetName("subscriptions_user_id_channel_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("subscriptions_user_id_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create subscriptions indexes: %w", err)
+	}
+
+	_, err = db.Collection("videos").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "channel_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("videos_channel_id_created_at_id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create videos channel index: %w", err)
+	}
+
+	return nil
+}
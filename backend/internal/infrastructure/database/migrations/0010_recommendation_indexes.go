@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createRecommendationIndexes serves the scans of recent views, reactions and watch history that
+// trending and related videos are computed from
+func createRecommendationIndexes(ctx context.Context, db *mongo.Database) error {
+	indexes := []struct {
+		collection string
+		model      mongo.IndexModel
+	}{
+		{"video_view_stats", mongo.IndexModel{
+			Keys:    bson.D{{Key: "day", Value: 1}},
+			Options: options.Index().SetName("video_view_stats_day"),
+		}},
+		{"reactions", mongo.IndexModel{
+			Keys:    bson.D{{Key: "updated_at", Value: 1}},
+			Options: options.Index().SetName("reactions_updated_at"),
+		}},
+		{"watch_history", mongo.IndexModel{
+			Keys:    bson.D{{Key: "watched_at", Value: 1}},
+			Options: options.Index().SetName("watch_history_watched_at"),
+		}},
+	}
+
+	for _, index := range indexes {
+		if _, err := db.Collection(index.collection).Indexes().CreateOne(ctx, index.model); err != nil {
+			return fmt.Errorf("failed to create %s index: %w", index.collection, err)
+		}
+	}
+
+	return nil
+}
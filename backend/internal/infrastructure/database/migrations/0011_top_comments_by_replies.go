@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexNotFound is the server error code for dropping an index that does not exist
+const indexNotFound = 27
+
+// rankTopCommentsByReplies replaces the like count index of the top comment ordering, which now ranks
+// threads by their replies
+func rankTopCommentsByReplies(ctx context.Context, db *mongo.Database) error {
+	indexes := db.Collection("comments").Indexes()
+
+	_, err := indexes.CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "video_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "reply_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("comments_video_id_parent_id_reply_count_created_at_id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comments index: %w", err)
+	}
+
+	var commandErr mongo.CommandError
+	if _, err := indexes.DropOne(ctx, "comments_video_id_parent_id_like_count_created_at_id"); err != nil && !(errors.As(err, &commandErr) && commandErr.Code == indexNotFound) {
+		return fmt.Errorf("failed to drop comments index: %w", err)
+	}
+
+	return nil
+}
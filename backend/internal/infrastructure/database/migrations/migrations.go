@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// appliedCollection records which migrations have run
+const appliedCollection = "schema_migrations"
+
+// Migration is a versioned, up-only schema change. Up must be safe to run again:
+// replicas starting together may apply the same migration concurrently.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// appliedMigration is the record stored once a migration has run
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// all lists every migration. New migrations get the next version number and are never edited once released.
+var all = []Migration{
+	{Version: 1, Description: "search and listing indexes", Up: createSearchAndListingIndexes},
+	{Version: 2, Description: "lookup indexes and unique users", Up: createLookupIndexes},
+	{Version: 3, Description: "tag and category indexes", Up: createCatalogIndexes},
+	{Version: 4, Description: "playlist indexes", Up: createPlaylistIndexes},
+	{Version: 5, Description: "comment indexes", Up: createCommentIndexes},
+	{Version: 6, Description: "reaction indexes", Up: createReactionIndexes},
+	{Version: 7, Description: "view statistics indexes", Up: createViewStatsIndexes},
+	{Version: 8, Description: "watch history indexes", Up: createWatchHistoryIndexes},
+	{Version: 9, Description: "channel and subscription indexes", Up: createChannelIndexes},
+	{Version: 10, Description: "recommendation indexes", Up: createRecommendationIndexes},
+	{Version: 11, Description: "top comments by replies", Up: rankTopCommentsByReplies},
+}
+
+// Run applies the migrations that have not run yet, in version order, and returns how many were applied
+func Run(ctx context.Context, db *database.MongoDB, logger *zap.Logger) (int, error) {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := make([]Migration, 0, len(all))
+	for _, migration := range all {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+
+	for i, migration := range pending {
+		logger.Info("Applying migration",
+			zap.Int("version", migration.Version),
+			zap.String("description", migration.Description))
+
+		if err := migration.Up(ctx, db.GetDatabase()); err != nil {
+			return i, fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		record := appliedMigration{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}
+		if _, err := db.GetCollection(appliedCollection).InsertOne(ctx, record); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return i, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+	}
+
+	return len(pending), nil
+}
+
+// appliedVersions returns the versions recorded as applied
+func appliedVersions(ctx context.Context, db *database.MongoDB) (map[int]bool, error) {
+	cursor, err := db.GetCollection(appliedCollection).Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	applied := make(map[int]bool)
+	for cursor.Next(ctx) {
+		var record appliedMigration
+		if err := cursor.Decode(&record); err != nil {
+			return nil, fmt.Errorf("failed to decode applied migration: %w", err)
+		}
+		applied[record.Version] = true
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return applied, nil
+}
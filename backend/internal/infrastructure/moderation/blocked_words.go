@@ -0,0 +1,47 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"youtube-backend/internal/domain/services"
+)
+
+// BlockedWordsFilter rejects text containing any word or phrase of a block list.
+// Matching ignores case and punctuation and only considers whole words, so blocking
+// "ass" does not reject "class".
+type BlockedWordsFilter struct {
+	phrases []string // normalized, each padded with spaces
+}
+
+// NewBlockedWordsFilter creates a filter for the given words and phrases; empty entries are ignored
+func NewBlockedWordsFilter(words []string) *BlockedWordsFilter {
+	filter := &BlockedWordsFilter{}
+	for _, word := range words {
+		if normalized := normalizeWords(word); normalized != "" {
+			filter.phrases = append(filter.phrases, " "+normalized+" ")
+		}
+	}
+	return filter
+}
+
+// Check implements services.ContentFilter
+func (f *BlockedWordsFilter) Check(ctx context.Context, text string) error {
+	padded := " " + normalizeWords(text) + " "
+	for _, phrase := range f.phrases {
+		if strings.Contains(padded, phrase) {
+			return fmt.Errorf("%w: contains blocked language", services.ErrCommentRejected)
+		}
+	}
+	return nil
+}
+
+// normalizeWords lowercases text and reduces it to its words separated by single spaces
+func normalizeWords(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const trendingKey = "recommendations:trending"
+
+// RecommendationCache holds the precomputed trending ranking and related videos in Redis.
+// Entries expire on their own if the background jobs stop refreshing them.
+type RecommendationCache struct {
+	redisClient *RedisClient
+}
+
+func NewRecommendationCache(redisClient *RedisClient) *RecommendationCache {
+	return &RecommendationCache{
+		redisClient: redisClient,
+	}
+}
+
+// SetTrending replaces the trending ranking. The new ranking is built under a temporary key and
+// renamed into place, so readers never see a partial one.
+func (c *RecommendationCache) SetTrending(ctx context.Context, ranked []entities.ScoredVideo, ttl time.Duration) error {
+	client := c.redisClient.GetClient()
+
+	if len(ranked) == 0 {
+		if err := client.Del(ctx, trendingKey).Err(); err != nil {
+			return fmt.Errorf("failed to clear trending videos: %w", err)
+		}
+		return nil
+	}
+
+	members := make([]*redis.Z, len(ranked))
+	for i, video := range ranked {
+		members[i] = &redis.Z{Score: video.Score, Member: video.VideoID.Hex()}
+	}
+
+	tempKey := trendingKey + ":next"
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tempKey)
+		pipe.ZAdd(ctx, tempKey, members...)
+		pipe.Expire(ctx, tempKey, ttl)
+		pipe.Rename(ctx, tempKey, trendingKey)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store trending videos: %w", err)
+	}
+	return nil
+}
+
+// GetTrending returns the IDs of the top trending videos, best first, and the size of the ranking
+func (c *RecommendationCache) GetTrending(ctx context.Context, limit int) ([]primitive.ObjectID, int64, error) {
+	client := c.redisClient.GetClient()
+
+	members, err := client.ZRevRange(ctx, trendingKey, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get trending videos: %w", err)
+	}
+	total, err := client.ZCard(ctx, trendingKey).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count trending videos: %w", err)
+	}
+
+	return parseObjectIDs(members), total, nil
+}
+
+// SetRelated replaces the related videos of several videos, best match first, in one round trip
+func (c *RecommendationCache) SetRelated(ctx context.Context, related map[primitive.ObjectID][]primitive.ObjectID, ttl time.Duration) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	// One transaction per batch, so readers see each list either old or new and never emptied in between
+	_, err := c.redisClient.GetClient().TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for videoID, ids := range related {
+			key := relatedKey(videoID)
+			pipe.Del(ctx, key)
+			if len(ids) == 0 {
+				continue
+			}
+
+			members := make([]interface{}, len(ids))
+			for i, id := range ids {
+				members[i] = id.Hex()
+			}
+			pipe.RPush(ctx, key, members...)
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store related videos: %w", err)
+	}
+	return nil
+}
+
+// GetRelated returns up to limit related videos of a video, best match first. It returns nil when
+// none were computed for the video.
+func (c *RecommendationCache) GetRelated(ctx context.Context, videoID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	members, err := c.redisClient.GetClient().LRange(ctx, relatedKey(videoID), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related videos: %w", err)
+	}
+	return parseObjectIDs(members), nil
+}
+
+// ClaimRefresh takes the named refresh job for the lease, so that only one backend runs it at a time.
+// It reports false while another backend holds the lease.
+func (c *RecommendationCache) ClaimRefresh(ctx context.Context, job string, lease time.Duration) (bool, error) {
+	claimed, err := c.redisClient.GetClient().SetNX(ctx, "recommendations:lock:"+job, 1, lease).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim %s refresh: %w", job, err)
+	}
+	return claimed, nil
+}
+
+func relatedKey(videoID primitive.ObjectID) string {
+	return "recommendations:related:" + videoID.Hex()
+}
+
+// parseObjectIDs converts stored hex IDs, skipping any that are malformed
+func parseObjectIDs(values []string) []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for _, value := range values {
+		if id, err := primitive.ObjectIDFromHex(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
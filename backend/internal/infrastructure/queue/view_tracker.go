@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ViewTracker keeps recent viewers and open view sessions in Redis, where they expire on their own
+type ViewTracker struct {
+	redisClient *RedisClient
+}
+
+func NewViewTracker(redisClient *RedisClient) *ViewTracker {
+	return &ViewTracker{
+		redisClient: redisClient,
+	}
+}
+
+// MarkViewed sets a key per video and viewer that lives for the dedup window. Only the first
+// viewing within the window creates it.
+func (t *ViewTracker) MarkViewed(ctx context.Context, videoID primitive.ObjectID, viewerKey string, window time.Duration) (bool, error) {
+	key := "views:seen:" + videoID.Hex() + ":" + viewerKey
+	created, err := t.redisClient.GetClient().SetNX(ctx, key, 1, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark video viewed: %w", err)
+	}
+	return created, nil
+}
+
+func (t *ViewTracker) SaveSession(ctx context.Context, session *entities.ViewSession, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := t.redisClient.GetClient().Set(ctx, viewSessionKey(session.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save view session: %w", err)
+	}
+	return nil
+}
+
+func (t *ViewTracker) GetSession(ctx context.Context, id string) (*entities.ViewSession, error) {
+	data, err := t.redisClient.GetClient().Get(ctx, viewSessionKey(id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get view session: %w", err)
+	}
+
+	var session entities.ViewSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode view session: %w", err)
+	}
+	return &session, nil
+}
+
+func (t *ViewTracker) DeleteSession(ctx context.Context, id string) error {
+	if err := t.redisClient.GetClient().Del(ctx, viewSessionKey(id)).Err(); err != nil {
+		return fmt.Errorf("failed to delete view session: %w", err)
+	}
+	return nil
+}
+
+func viewSessionKey(id string) string {
+	return "views:session:" + id
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type APIKeyRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository(db *database.MongoDB) repositories.APIKeyRepository {
+	return &APIKeyRepositoryImpl{
+		collection: db.GetCollection("api_keys"),
+	}
+}
+
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *entities.APIKey) error {
+	_, err := r.collection.InsertOne(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Return nil without error for "not found" case
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]*entities.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*entities.APIKey
+	for cursor.Next(ctx) {
+		var key entities.APIKey
+		if err := cursor.Decode(&key); err != nil {
+			return nil, fmt.Errorf("failed to decode api key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+
+	return keys, nil
+}
+
+// Revoke marks a user's key as revoked and reports whether this call revoked it
+func (r *APIKeyRepositoryImpl) Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "user_id": userID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// TouchLastUsed records a use of the key, writing at most once per interval
+func (r *APIKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time, interval time.Duration) error {
+	filter := bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"last_used_at": bson.M{"$exists": false}},
+			{"last_used_at": bson.M{"$lt": usedAt.Add(-interval)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"last_used_at": usedAt}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BatchRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewBatchRepository(db *database.MongoDB) repositories.BatchRepository {
+	return &BatchRepositoryImpl{
+		collection: db.GetCollection("batches"),
+	}
+}
+
+func (r *BatchRepositoryImpl) Create(ctx context.Context, batch *entities.Batch) error {
+	_, err := r.collection.InsertOne(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+	return nil
+}
+
+func (r *BatchRepositoryImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Batch, error) {
+	var batch entities.Batch
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&batch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("batch not found")
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	return &batch, nil
+}
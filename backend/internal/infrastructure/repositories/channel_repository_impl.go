@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChannelRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewChannelRepository(db *database.MongoDB) repositories.ChannelRepository {
+	return &ChannelRepositoryImpl{
+		collection: db.GetCollection("channels"),
+	}
+}
+
+func (r *ChannelRepositoryImpl) Create(ctx context.Context, channel *entities.Channel) error {
+	_, err := r.collection.InsertOne(ctx, channel)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("handle already exists: %w", repositories.ErrDuplicate)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create channel: %w", err)
+	}
+	return nil
+}
+
+func (r *ChannelRepositoryImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Channel, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *ChannelRepositoryImpl) GetByHandle(ctx context.Context, handle string) (*entities.Channel, error) {
+	return r.findOne(ctx, bson.M{"handle": handle})
+}
+
+func (r *ChannelRepositoryImpl) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Channel, error) {
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": ids}}, nil)
+}
+
+func (r *ChannelRepositoryImpl) ListByOwner(ctx context.Context, owner string) ([]*entities.Channel, error) {
+	return r.find(ctx, bson.M{"owner": owner}, options.Find().SetSort(oldestFirst))
+}
+
+func (r *ChannelRepositoryImpl) UpdateProfile(ctx context.Context, id primitive.ObjectID, name, description string) error {
+	update := bson.M{
+		"$set": bson.M{"name": name, "description": description, "updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update channel: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("channel not found")
+	}
+
+	return nil
+}
+
+// SetImage swaps the image object and reads the document as it was before, so the replaced object can be deleted
+func (r *ChannelRepositoryImpl) SetImage(ctx context.Context, id primitive.ObjectID, image entities.ChannelImage, objectName string) (string, error) {
+	update := bson.M{
+		"$set": bson.M{string(image) + "_object": objectName, "updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous entities.Channel
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", fmt.Errorf("channel not found")
+		}
+		return "", fmt.Errorf("failed to update channel image: %w", err)
+	}
+	return previous.ImageObject(image), nil
+}
+
+func (r *ChannelRepositoryImpl) IncrementSubscribers(ctx context.Context, id primitive.ObjectID, delta int64) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"subscriber_count": delta}})
+	if err != nil {
+		return fmt.Errorf("failed to update subscriber count: %w", err)
+	}
+	return nil
+}
+
+func (r *ChannelRepositoryImpl) findOne(ctx context.Context, filter bson.M) (*entities.Channel, error) {
+	var channel entities.Channel
+	err := r.collection.FindOne(ctx, filter).Decode(&channel)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	return &channel, nil
+}
+
+func (r *ChannelRepositoryImpl) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*entities.Channel, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	channels := []*entities.Channel{}
+	if err := cursor.All(ctx, &channels); err != nil {
+		return nil, fmt.Errorf("failed to decode channels: %w", err)
+	}
+	return channels, nil
+}
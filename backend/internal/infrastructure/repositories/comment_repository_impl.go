@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewCommentRepository(db *database.MongoDB) repositories.CommentRepository {
+	return &CommentRepositoryImpl{
+		collection: db.GetCollection("comments"),
+	}
+}
+
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entities.Comment) error {
+	_, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepositoryImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Comment, error) {
+	var comment entities.Comment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepositoryImpl) UpdateBody(ctx context.Context, id primitive.ObjectID, body string) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"body": body, "edited_at": now, "updated_at": now},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("comment not found")
+	}
+
+	return nil
+}
+
+func (r *CommentRepositoryImpl) SetStatus(ctx context.Context, id primitive.ObjectID, status entities.CommentStatus, removedBy string) (bool, error) {
+	set := bson.M{"status": status, "updated_at": time.Now()}
+	switch status {
+	case entities.CommentStatusDeleted:
+		set["body"] = ""
+	case entities.CommentStatusRemoved:
+		set["removed_by"] = removedBy
+	}
+
+	// Only a visible comment changes, so concurrent deletes hide it once
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": entities.CommentStatusVisible}, bson.M{"$set": set})
+	if err != nil {
+		return false, fmt.Errorf("failed to update comment status: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *CommentRepositoryImpl) IncrementReplyCount(ctx context.Context, id primitive.ObjectID, delta int) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"reply_count": delta}})
+	if err != nil {
+		return fmt.Errorf("failed to update reply count: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepositoryImpl) List(ctx context.Context, filter repositories.CommentFilter, sort repositories.CommentSort, limit int, after *repositories.PageCursor) ([]*entities.Comment, *repositories.PageCursor, error) {
+	conditions := commentFilterConditions(filter)
+
+	order := newestFirst
+	switch sort {
+	case repositories.CommentSortOldest:
+		order = oldestFirst
+		if after != nil {
+			conditions = append(conditions, afterCursorOldestFirst(after))
+		}
+	case repositories.CommentSortTop:
+		order = append(bson.D{{Key: "reply_count", Value: -1}}, newestFirst...)
+		if after != nil {
+			conditions = append(conditions, afterRankedCursor("reply_count", after))
+		}
+	default:
+		if after != nil {
+			conditions = append(conditions, afterCursor(after))
+		}
+	}
+
+	// One extra comment tells whether another page follows
+	opts := options.Find().
+		SetLimit(int64(limit) + 1).
+		SetSort(order)
+
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*entities.Comment
+	for cursor.Next(ctx) {
+		var comment entities.Comment
+		if err := cursor.Decode(&comment); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	if len(comments) <= limit {
+		return comments, nil, nil
+	}
+	last := comments[limit-1]
+	next := &repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	if sort == repositories.CommentSortTop {
+		next.Score = float64(last.ReplyCount)
+	}
+	return comments[:limit], next, nil
+}
+
+func (r *CommentRepositoryImpl) Count(ctx context.Context, filter repositories.CommentFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, matchAll(commentFilterConditions(filter)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	return count, nil
+}
+
+func (r *CommentRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID})
+	if err != nil {
+		return fmt.Errorf("failed to delete comments: %w", err)
+	}
+	return nil
+}
+
+// commentFilterConditions translates a CommentFilter into MongoDB query conditions
+func commentFilterConditions(filter repositories.CommentFilter) []bson.M {
+	var conditions []bson.M
+
+	if filter.VideoID != nil {
+		conditions = append(conditions, bson.M{"video_id": *filter.VideoID})
+	}
+
+	if filter.ParentID != nil {
+		conditions = append(conditions, bson.M{"parent_id": *filter.ParentID})
+	} else if filter.TopLevel {
+		conditions = append(conditions, bson.M{"parent_id": nil})
+	}
+
+	if filter.Listed {
+		// A hidden comment stays as a placeholder while its thread has replies
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"status": entities.CommentStatusVisible},
+			{"reply_count": bson.M{"$gt": 0}},
+		}})
+	}
+
+	return conditions
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ContentKeyRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewContentKeyRepository(db *database.MongoDB) repositories.ContentKeyRepository {
+	return &ContentKeyRepositoryImpl{
+		collection: db.GetCollection("content_keys"),
+	}
+}
+
+func (r *ContentKeyRepositoryImpl) GetByID(ctx context.Context, id string) (*entities.ContentKey, error) {
+	var key entities.ContentKey
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Return nil without error for "not found" case
+		}
+		return nil, fmt.Errorf("failed to get content key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *ContentKeyRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID}); err != nil {
+		return fmt.Errorf("failed to delete content keys: %w", err)
+	}
+	return nil
+}
@@ -94,6 +94,26 @@ func (r *JobRepositoryImpl) GetByVideoID(ctx context.Context, videoID primitive.
 	return jobs, nil
 }
 
+func (r *JobRepositoryImpl) GetByVideoIDs(ctx context.Context, videoIDs []primitive.ObjectID) ([]*entities.Job, error) {
+	filter := bson.M{"video_id": bson.M{"$in": videoIDs}}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs by video IDs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*entities.Job
+	for cursor.Next(ctx) {
+		var job entities.Job
+		if err := cursor.Decode(&job); err != nil {
+			return nil, fmt.Errorf("failed to decode job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
 func (r *JobRepositoryImpl) GetByStatus(ctx context.Context, status entities.JobStatus) ([]*entities.Job, error) {
 	filter := bson.M{"status": status}
 	cursor, err := r.collection.Find(ctx, filter)
@@ -138,11 +158,20 @@ func (r *JobRepositoryImpl) GetPendingJobs(ctx context.Context, limit int) ([]*e
 	return jobs, nil
 }
 
-func (r *JobRepositoryImpl) GetActiveJobs(ctx context.Context) ([]*entities.Job, error) {
-	filter := bson.M{"status": entities.JobStatusProcessing}
-	cursor, err := r.collection.Find(ctx, filter)
+func (r *JobRepositoryImpl) List(ctx context.Context, filter repositories.JobFilter, limit int, after *repositories.PageCursor) ([]*entities.Job, *repositories.PageCursor, error) {
+	conditions := jobFilterConditions(filter)
+	if after != nil {
+		conditions = append(conditions, afterCursor(after))
+	}
+
+	// One extra job tells whether another page follows
+	opts := options.Find().
+		SetLimit(int64(limit) + 1).
+		SetSort(newestFirst)
+
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active jobs: %w", err)
+		return nil, nil, fmt.Errorf("failed to list jobs: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -150,12 +179,28 @@ func (r *JobRepositoryImpl) GetActiveJobs(ctx context.Context) ([]*entities.Job,
 	for cursor.Next(ctx) {
 		var job entities.Job
 		if err := cursor.Decode(&job); err != nil {
-			return nil, fmt.Errorf("failed to decode job: %w", err)
+			return nil, nil, fmt.Errorf("failed to decode job: %w", err)
 		}
 		jobs = append(jobs, &job)
 	}
 
-	return jobs, nil
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	if len(jobs) <= limit {
+		return jobs, nil, nil
+	}
+	last := jobs[limit-1]
+	return jobs[:limit], &repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+func (r *JobRepositoryImpl) Count(ctx context.Context, filter repositories.JobFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, matchAll(jobFilterConditions(filter)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	return count, nil
 }
 
 func (r *JobRepositoryImpl) UpdateProgress(ctx context.Context, id primitive.ObjectID, progress int) error {
@@ -178,3 +223,42 @@ func (r *JobRepositoryImpl) UpdateProgress(ctx context.Context, id primitive.Obj
 
 	return nil
 }
+
+// CancelPendingByVideoID cancels the jobs of a video that no worker has picked up yet
+func (r *JobRepositoryImpl) CancelPendingByVideoID(ctx context.Context, videoID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"video_id": videoID, "status": entities.JobStatusPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     entities.JobStatusCancelled,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel jobs: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *JobRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID}); err != nil {
+		return fmt.Errorf("failed to delete jobs: %w", err)
+	}
+	return nil
+}
+
+// jobFilterConditions translates a JobFilter into MongoDB query conditions
+func jobFilterConditions(filter repositories.JobFilter) []bson.M {
+	var conditions []bson.M
+
+	if filter.VideoID != nil {
+		conditions = append(conditions, bson.M{"video_id": *filter.VideoID})
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, bson.M{"status": filter.Status})
+	}
+
+	return conditions
+}
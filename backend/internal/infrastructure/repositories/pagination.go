@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newestFirst is the order of cursor-paginated listings. _id breaks ties between documents created at the same instant.
+var newestFirst = bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+
+// afterCursor matches the documents that follow the cursor in newestFirst order
+func afterCursor(after *repositories.PageCursor) bson.M {
+	return afterTimeCursor("created_at", after)
+}
+
+// afterTimeCursor matches the documents that follow the cursor when sorted by a descending time
+// field, then _id. The cursor's CreatedAt holds the value of that field.
+func afterTimeCursor(field string, after *repositories.PageCursor) bson.M {
+	return bson.M{"$or": []bson.M{
+		{field: bson.M{"$lt": after.CreatedAt}},
+		{field: after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+	}}
+}
+
+// oldestFirst is the reverse of newestFirst
+var oldestFirst = bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+
+// afterCursorOldestFirst matches the documents that follow the cursor in oldestFirst order
+func afterCursorOldestFirst(after *repositories.PageCursor) bson.M {
+	return bson.M{"$or": []bson.M{
+		{"created_at": bson.M{"$gt": after.CreatedAt}},
+		{"created_at": after.CreatedAt, "_id": bson.M{"$gt": after.ID}},
+	}}
+}
+
+// afterRankedCursor matches the documents that follow the cursor when sorted by a descending
+// numeric field, then in newestFirst order. The cursor's Score holds the value of that field.
+func afterRankedCursor(field string, after *repositories.PageCursor) bson.M {
+	return bson.M{"$or": []bson.M{
+		{field: bson.M{"$lt": after.Score}},
+		{field: after.Score, "created_at": bson.M{"$lt": after.CreatedAt}},
+		{field: after.Score, "created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+	}}
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"youtube-backend/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// rankedDoc is a document of a listing sorted by a descending score, then newest first
+type rankedDoc struct {
+	score     float64
+	createdAt time.Time
+	id        primitive.ObjectID
+}
+
+func (d rankedDoc) field(name string) any {
+	switch name {
+	case "score":
+		return d.score
+	case "created_at":
+		return d.createdAt
+	default:
+		return d.id
+	}
+}
+
+// compareValues orders the values of the fields a ranked cursor filters on
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	default:
+		aID, bID := a.(primitive.ObjectID), b.(primitive.ObjectID)
+		return bytes.Compare(aID[:], bID[:])
+	}
+}
+
+// matchesFilter evaluates the $or, equality and $lt conditions that cursor filters are built from
+func matchesFilter(t *testing.T, filter bson.M, doc rankedDoc) bool {
+	t.Helper()
+	for key, condition := range filter {
+		if key == "$or" {
+			matched := false
+			for _, clause := range condition.([]bson.M) {
+				if matchesFilter(t, clause, doc) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+
+		if operators, ok := condition.(bson.M); ok {
+			for operator, value := range operators {
+				if operator != "$lt" {
+					t.Fatalf("unexpected operator %s", operator)
+				}
+				if compareValues(doc.field(key), value) >= 0 {
+					return false
+				}
+			}
+			continue
+		}
+		if compareValues(doc.field(key), condition) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAfterRankedCursorPaging(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	id := func(n byte) primitive.ObjectID { return primitive.ObjectID{11: n} }
+
+	// Ties on score, on score and time, and a score above and below them all
+	docs := []rankedDoc{
+		{score: 5, createdAt: base, id: id(1)},
+		{score: 5, createdAt: base, id: id(2)},
+		{score: 5, createdAt: base.Add(time.Minute), id: id(3)},
+		{score: 2, createdAt: base.Add(2 * time.Minute), id: id(4)},
+		{score: 2, createdAt: base.Add(2 * time.Minute), id: id(5)},
+		{score: 9, createdAt: base.Add(-time.Hour), id: id(6)},
+		{score: 0, createdAt: base.Add(time.Hour), id: id(7)},
+		{score: 2, createdAt: base.Add(-time.Minute), id: id(8)},
+	}
+
+	want := append([]rankedDoc(nil), docs...)
+	sort.Slice(want, func(i, j int) bool {
+		if c := compareValues(want[i].score, want[j].score); c != 0 {
+			return c > 0
+		}
+		if c := compareValues(want[i].createdAt, want[j].createdAt); c != 0 {
+			return c > 0
+		}
+		return compareValues(want[i].id, want[j].id) > 0
+	})
+
+	for _, limit := range []int{1, 2, 3, len(docs), len(docs) + 1} {
+		var got []rankedDoc
+		var after *repositories.PageCursor
+		for page := 0; page <= len(docs); page++ {
+			// The next page is the first limit documents, in order, that follow the cursor
+			var pageDocs []rankedDoc
+			for _, doc := range want {
+				if after == nil || matchesFilter(t, afterRankedCursor("score", after), doc) {
+					pageDocs = append(pageDocs, doc)
+				}
+			}
+			if len(pageDocs) > limit {
+				pageDocs = pageDocs[:limit]
+			}
+			got = append(got, pageDocs...)
+			if len(pageDocs) < limit {
+				break
+			}
+			last := pageDocs[len(pageDocs)-1]
+			after = &repositories.PageCursor{Score: last.score, CreatedAt: last.createdAt, ID: last.id}
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("limit %d: paged through %d documents, want %d", limit, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].id != want[i].id {
+				t.Errorf("limit %d: document %d = %v, want %v", limit, i, got[i].id, want[i].id)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PlanRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewPlanRepository(db *database.MongoDB) repositories.PlanRepository {
+	return &PlanRepositoryImpl{
+		collection: db.GetCollection("plans"),
+	}
+}
+
+func (r *PlanRepositoryImpl) Create(ctx context.Context, plan *entities.Plan) error {
+	existingPlan, _ := r.GetByName(ctx, plan.Name)
+	if existingPlan != nil {
+		return fmt.Errorf("plan already exists")
+	}
+
+	_, err := r.collection.InsertOne(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("failed to create plan: %w", err)
+	}
+	return nil
+}
+
+func (r *PlanRepositoryImpl) GetByName(ctx context.Context, name string) (*entities.Plan, error) {
+	var plan entities.Plan
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&plan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Return nil without error for "not found" case
+		}
+		return nil, fmt.Errorf("failed to get plan by name: %w", err)
+	}
+	return &plan, nil
+}
+
+func (r *PlanRepositoryImpl) Update(ctx context.Context, plan *entities.Plan) error {
+	filter := bson.M{"_id": plan.ID}
+	update := bson.M{"$set": plan}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("plan not found")
+	}
+
+	return nil
+}
+
+func (r *PlanRepositoryImpl) List(ctx context.Context) ([]*entities.Plan, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plans: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var plans []*entities.Plan
+	for cursor.Next(ctx) {
+		var plan entities.Plan
+		if err := cursor.Decode(&plan); err != nil {
+			return nil, fmt.Errorf("failed to decode plan: %w", err)
+		}
+		plans = append(plans, &plan)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return plans, nil
+}
@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PlaylistRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewPlaylistRepository(db *database.MongoDB) repositories.PlaylistRepository {
+	return &PlaylistRepositoryImpl{
+		collection: db.GetCollection("playlists"),
+	}
+}
+
+func (r *PlaylistRepositoryImpl) Create(ctx context.Context, playlist *entities.Playlist) error {
+	_, err := r.collection.InsertOne(ctx, playlist)
+	if err != nil {
+		return fmt.Errorf("failed to create playlist: %w", err)
+	}
+	return nil
+}
+
+func (r *PlaylistRepositoryImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Playlist, error) {
+	var playlist entities.Playlist
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&playlist)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get playlist: %w", err)
+	}
+	return &playlist, nil
+}
+
+func (r *PlaylistRepositoryImpl) UpdateMetadata(ctx context.Context, id primitive.ObjectID, title, description string, visibility entities.Visibility) error {
+	update := bson.M{
+		"$set": bson.M{
+			"title":       title,
+			"description": description,
+			"visibility":  visibility,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("playlist not found")
+	}
+
+	return nil
+}
+
+func (r *PlaylistRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("playlist not found")
+	}
+
+	return nil
+}
+
+func (r *PlaylistRepositoryImpl) List(ctx context.Context, filter repositories.PlaylistFilter, limit int, after *repositories.PageCursor) ([]*entities.Playlist, *repositories.PageCursor, error) {
+	conditions := playlistFilterConditions(filter)
+	if after != nil {
+		conditions = append(conditions, afterCursor(after))
+	}
+
+	// One extra playlist tells whether another page follows
+	opts := options.Find().
+		SetLimit(int64(limit) + 1).
+		SetSort(newestFirst)
+
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list playlists: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var playlists []*entities.Playlist
+	for cursor.Next(ctx) {
+		var playlist entities.Playlist
+		if err := cursor.Decode(&playlist); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode playlist: %w", err)
+		}
+		playlists = append(playlists, &playlist)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	if len(playlists) <= limit {
+		return playlists, nil, nil
+	}
+	last := playlists[limit-1]
+	return playlists[:limit], &repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+func (r *PlaylistRepositoryImpl) Count(ctx context.Context, filter repositories.PlaylistFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, matchAll(playlistFilterConditions(filter)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count playlists: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PlaylistRepositoryImpl) AddVideo(ctx context.Context, id, videoID primitive.ObjectID, position *int, maxVideos int) (bool, error) {
+	push := bson.M{"$each": []primitive.ObjectID{videoID}}
+	if position != nil {
+		push["$position"] = *position
+	}
+
+	// Matching only playlists without the video and without an entry at the last allowed index makes the
+	// duplicate check, the size limit and the insert one atomic step
+	lastIndex := fmt.Sprintf("video_ids.%d", maxVideos-1)
+	filter := bson.M{"_id": id, "video_ids": bson.M{"$ne": videoID}, lastIndex: bson.M{"$exists": false}}
+	update := bson.M{
+		"$push": bson.M{"video_ids": push},
+		"$inc":  bson.M{"version": 1},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to add video to playlist: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *PlaylistRepositoryImpl) RemoveVideo(ctx context.Context, id, videoID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "video_ids": videoID}
+	update := bson.M{
+		"$pull": bson.M{"video_ids": videoID},
+		"$inc":  bson.M{"version": 1},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove video from playlist: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *PlaylistRepositoryImpl) ReorderVideos(ctx context.Context, id primitive.ObjectID, version int, videoIDs []primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "version": version}
+	update := bson.M{
+		"$set": bson.M{"video_ids": videoIDs, "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to reorder playlist: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *PlaylistRepositoryImpl) RemoveVideoFromAll(ctx context.Context, videoID primitive.ObjectID) error {
+	filter := bson.M{"video_ids": videoID}
+	update := bson.M{
+		"$pull": bson.M{"video_ids": videoID},
+		"$inc":  bson.M{"version": 1},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to remove video from playlists: %w", err)
+	}
+	return nil
+}
+
+// playlistFilterConditions translates a PlaylistFilter into MongoDB query conditions
+func playlistFilterConditions(filter repositories.PlaylistFilter) []bson.M {
+	var conditions []bson.M
+
+	if filter.Owner != "" {
+		conditions = append(conditions, bson.M{"owner": filter.Owner})
+	}
+
+	if filter.PublicOnly {
+		conditions = append(conditions, bson.M{"visibility": entities.VisibilityPublic})
+	}
+
+	return conditions
+}
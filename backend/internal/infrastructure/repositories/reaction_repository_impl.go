@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReactionRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewReactionRepository(db *database.MongoDB) repositories.ReactionRepository {
+	return &ReactionRepositoryImpl{
+		collection: db.GetCollection("reactions"),
+	}
+}
+
+func (r *ReactionRepositoryImpl) Get(ctx context.Context, videoID primitive.ObjectID, userID string) (*entities.Reaction, error) {
+	var reaction entities.Reaction
+	err := r.collection.FindOne(ctx, bson.M{"video_id": videoID, "user_id": userID}).Decode(&reaction)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reaction: %w", err)
+	}
+	return &reaction, nil
+}
+
+// Set upserts the reaction and reads the document as it was before, so concurrent requests of the
+// same user each see the reaction they actually replaced
+func (r *ReactionRepositoryImpl) Set(ctx context.Context, videoID primitive.ObjectID, userID string, reactionType entities.ReactionType) (entities.ReactionType, error) {
+	now := time.Now()
+	update := bson.M{
+		"$set":         bson.M{"type": reactionType, "updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	var previous entities.Reaction
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"video_id": videoID, "user_id": userID}, update, opts).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to set reaction: %w", err)
+	}
+	return previous.Type, nil
+}
+
+func (r *ReactionRepositoryImpl) Delete(ctx context.Context, videoID primitive.ObjectID, userID string) (entities.ReactionType, error) {
+	var previous entities.Reaction
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"video_id": videoID, "user_id": userID}).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	return previous.Type, nil
+}
+
+func (r *ReactionRepositoryImpl) Count(ctx context.Context, videoID primitive.ObjectID) (entities.ReactionCounts, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"video_id": videoID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$type", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return entities.ReactionCounts{}, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Type  entities.ReactionType `bson:"_id"`
+		Count int64                 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return entities.ReactionCounts{}, fmt.Errorf("failed to decode reaction counts: %w", err)
+	}
+
+	var counts entities.ReactionCounts
+	for _, result := range results {
+		counts = counts.Add(result.Type, result.Count)
+	}
+	return counts, nil
+}
+
+func (r *ReactionRepositoryImpl) CountDailySince(ctx context.Context, since time.Time) ([]*entities.DailyReactions, error) {
+	countOf := func(reactionType entities.ReactionType) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", reactionType}}, 1, 0}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"updated_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"video_id": "$video_id",
+				"day":      bson.M{"$dateTrunc": bson.M{"date": "$updated_at", "unit": "day"}},
+			},
+			"likes":    countOf(entities.ReactionLike),
+			"dislikes": countOf(entities.ReactionDislike),
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "video_id": "$_id.video_id", "day": "$_id.day", "likes": 1, "dislikes": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent reactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var counts []*entities.DailyReactions
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode reaction counts: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *ReactionRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID})
+	if err != nil {
+		return fmt.Errorf("failed to delete reactions: %w", err)
+	}
+	return nil
+}
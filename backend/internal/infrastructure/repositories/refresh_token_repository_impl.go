@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *database.MongoDB) repositories.RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{
+		collection: db.GetCollection("refresh_tokens"),
+	}
+}
+
+func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *entities.RefreshToken) error {
+	_, err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Return nil without error for "not found" case
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// Revoke marks a token as revoked and reports whether this call revoked it
+func (r *RefreshTokenRepositoryImpl) Revoke(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error {
+	filter := bson.M{"user_id": userID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
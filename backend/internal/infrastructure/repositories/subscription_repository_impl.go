@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SubscriptionRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewSubscriptionRepository(db *database.MongoDB) repositories.SubscriptionRepository {
+	return &SubscriptionRepositoryImpl{
+		collection: db.GetCollection("subscriptions"),
+	}
+}
+
+// Create relies on the unique user and channel index, so concurrent subscribes store one subscription
+func (r *SubscriptionRepositoryImpl) Create(ctx context.Context, subscription *entities.Subscription) (bool, error) {
+	_, err := r.collection.InsertOne(ctx, subscription)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	return true, nil
+}
+
+func (r *SubscriptionRepositoryImpl) Delete(ctx context.Context, userID string, channelID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "channel_id": channelID})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *SubscriptionRepositoryImpl) Exists(ctx context.Context, userID string, channelID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "channel_id": channelID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check subscription: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *SubscriptionRepositoryImpl) List(ctx context.Context, userID string, limit int, after *repositories.PageCursor) ([]*entities.Subscription, *repositories.PageCursor, error) {
+	conditions := []bson.M{{"user_id": userID}}
+	if after != nil {
+		conditions = append(conditions, afterCursor(after))
+	}
+
+	// One extra subscription tells whether another page follows
+	opts := options.Find().
+		SetLimit(int64(limit) + 1).
+		SetSort(newestFirst)
+
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []*entities.Subscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode subscriptions: %w", err)
+	}
+
+	if len(subscriptions) <= limit {
+		return subscriptions, nil, nil
+	}
+	last := subscriptions[limit-1]
+	return subscriptions[:limit], &repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+func (r *SubscriptionRepositoryImpl) Count(ctx context.Context, userID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SubscriptionRepositoryImpl) ListChannelIDs(ctx context.Context, userID string, limit int) ([]primitive.ObjectID, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(newestFirst).
+		SetProjection(bson.M{"channel_id": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribed channels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []entities.Subscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode subscriptions: %w", err)
+	}
+
+	channelIDs := make([]primitive.ObjectID, len(subscriptions))
+	for i, subscription := range subscriptions {
+		channelIDs[i] = subscription.ChannelID
+	}
+	return channelIDs, nil
+}
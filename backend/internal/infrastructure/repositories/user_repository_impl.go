@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"youtube-backend/internal/domain/entities"
 	"youtube-backend/internal/domain/repositories"
@@ -37,6 +38,10 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *entities.User) er
 	}
 
 	_, err := r.collection.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent registration took the username or email after the checks above
+		return fmt.Errorf("username or email already exists: %w", repositories.ErrDuplicate)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -95,6 +100,28 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, user *entities.User) er
 	return nil
 }
 
+// SetHistoryPaused sets only the paused flag, which Update leaves in place when it is cleared
+func (r *UserRepositoryImpl) SetHistoryPaused(ctx context.Context, id primitive.ObjectID, paused bool) error {
+	return r.setFields(ctx, id, bson.M{"history_paused": paused, "updated_at": time.Now()})
+}
+
+func (r *UserRepositoryImpl) SetHistorySince(ctx context.Context, id primitive.ObjectID, since time.Time) error {
+	return r.setFields(ctx, id, bson.M{"history_since": since, "updated_at": time.Now()})
+}
+
+func (r *UserRepositoryImpl) setFields(ctx context.Context, id primitive.ObjectID, fields bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": fields})
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID) error {
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"youtube-backend/internal/domain/entities"
 	"youtube-backend/internal/domain/repositories"
@@ -44,9 +47,48 @@ func (r *VideoRepositoryImpl) GetByID(ctx context.Context, id primitive.ObjectID
 	return &video, nil
 }
 
+func (r *VideoRepositoryImpl) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Video, error) {
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get videos by IDs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var videos []*entities.Video
+	for cursor.Next(ctx) {
+		var video entities.Video
+		if err := cursor.Decode(&video); err != nil {
+			return nil, fmt.Errorf("failed to decode video: %w", err)
+		}
+		videos = append(videos, &video)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return videos, nil
+}
+
+// Update writes every field of the video except its counters, which only change through IncrementViews
+// and IncrementReactions, its captions, which only change through SetCaption and RemoveCaption, its
+// chapters, which only change through SetChapters, and its thumbnail choice, which only changes through
+// SetPrimaryThumbnail and SetCustomThumbnail
 func (r *VideoRepositoryImpl) Update(ctx context.Context, video *entities.Video) error {
+	fields, err := toDocument(video)
+	if err != nil {
+		return fmt.Errorf("failed to encode video: %w", err)
+	}
+	delete(fields, "view_count")
+	delete(fields, "like_count")
+	delete(fields, "dislike_count")
+	delete(fields, "captions")
+	delete(fields, "chapters")
+	delete(fields, "primary_thumbnail")
+	delete(fields, "custom_thumbnail")
+
 	filter := bson.M{"_id": video.ID}
-	update := bson.M{"$set": video}
+	update := bson.M{"$set": fields}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -60,6 +102,176 @@ func (r *VideoRepositoryImpl) Update(ctx context.Context, video *entities.Video)
 	return nil
 }
 
+// UpdateMetadata changes only the descriptive fields, so it never overwrites renditions the worker adds concurrently
+func (r *VideoRepositoryImpl) UpdateMetadata(ctx context.Context, id primitive.ObjectID, title, description, category string, tags []string) error {
+	set := bson.M{
+		"title":       title,
+		"description": description,
+		"tags":        tags,
+		"updated_at":  time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if category != "" {
+		set["category"] = category
+	} else {
+		update["$unset"] = bson.M{"category": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update video metadata: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+
+	return nil
+}
+
+// SetCaption replaces the track in place when the language exists and appends it otherwise. The
+// language condition on each update keeps concurrent uploads from adding the same language twice.
+func (r *VideoRepositoryImpl) SetCaption(ctx context.Context, id primitive.ObjectID, caption entities.Caption) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		replaced, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": id, "captions.language": caption.Language},
+			bson.M{"$set": bson.M{"captions.$": caption, "updated_at": time.Now()}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to replace caption: %w", err)
+		}
+		if replaced.MatchedCount > 0 {
+			return nil
+		}
+
+		added, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": id, "captions.language": bson.M{"$ne": caption.Language}},
+			bson.M{"$push": bson.M{"captions": caption}, "$set": bson.M{"updated_at": time.Now()}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add caption: %w", err)
+		}
+		if added.MatchedCount > 0 {
+			return nil
+		}
+		// Either the video is gone or another upload added the language meanwhile; retry the replace
+	}
+
+	return fmt.Errorf("video not found")
+}
+
+func (r *VideoRepositoryImpl) RemoveCaption(ctx context.Context, id primitive.ObjectID, language string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "captions.language": language},
+		bson.M{"$pull": bson.M{"captions": bson.M{"language": language}}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove caption: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetChapters replaces the chapters of a video; no chapters removes them
+func (r *VideoRepositoryImpl) SetChapters(ctx context.Context, id primitive.ObjectID, chapters []entities.Chapter) error {
+	update := bson.M{"$set": bson.M{"chapters": chapters, "updated_at": time.Now()}}
+	if len(chapters) == 0 {
+		update = bson.M{"$unset": bson.M{"chapters": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to set chapters: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+	return nil
+}
+
+func (r *VideoRepositoryImpl) SetPrimaryThumbnail(ctx context.Context, id primitive.ObjectID, object string) error {
+	update := bson.M{"$set": bson.M{"primary_thumbnail": object, "updated_at": time.Now()}}
+	if object == "" {
+		update = bson.M{"$unset": bson.M{"primary_thumbnail": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to set primary thumbnail: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+	return nil
+}
+
+func (r *VideoRepositoryImpl) SetCustomThumbnail(ctx context.Context, id primitive.ObjectID, thumbnail entities.CustomThumbnail) (*entities.CustomThumbnail, error) {
+	// A pipeline update reads the size of the replaced thumbnail in the same write that drops it
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"thumbnails_size": bson.M{"$subtract": bson.A{
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$thumbnails_size", 0}}, thumbnail.Size}},
+				bson.M{"$ifNull": bson.A{"$custom_thumbnail.size", 0}},
+			}},
+			"custom_thumbnail":  bson.M{"$literal": thumbnail},
+			"primary_thumbnail": bson.M{"$literal": thumbnail.Object},
+			"updated_at":        time.Now(),
+		}}},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.Before).
+		SetProjection(bson.M{"custom_thumbnail": 1})
+
+	var previous struct {
+		CustomThumbnail *entities.CustomThumbnail `bson:"custom_thumbnail"`
+	}
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&previous); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("video not found")
+		}
+		return nil, fmt.Errorf("failed to set custom thumbnail: %w", err)
+	}
+	return previous.CustomThumbnail, nil
+}
+
+func (r *VideoRepositoryImpl) SetVisibility(ctx context.Context, id primitive.ObjectID, visibility entities.Visibility, publishAt *time.Time) error {
+	set := bson.M{"visibility": visibility, "updated_at": time.Now()}
+	update := bson.M{"$set": set}
+	if publishAt != nil {
+		set["publish_at"] = *publishAt
+	} else {
+		update["$unset"] = bson.M{"publish_at": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update video visibility: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+
+	return nil
+}
+
+func (r *VideoRepositoryImpl) SetChannel(ctx context.Context, id primitive.ObjectID, channelID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{"channel_id": channelID, "updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update video channel: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+
+	return nil
+}
+
 func (r *VideoRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID) error {
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
@@ -73,15 +285,40 @@ func (r *VideoRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID)
 	return nil
 }
 
-func (r *VideoRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*entities.Video, error) {
+// SetDeletedAt moves a video into the trash, or restores it when deletedAt is nil
+func (r *VideoRepositoryImpl) SetDeletedAt(ctx context.Context, id primitive.ObjectID, deletedAt *time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"deleted_at": deletedAt, "updated_at": time.Now()},
+	}
+	if deletedAt == nil {
+		update = bson.M{
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$unset": bson.M{"deleted_at": ""},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update video deletion: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("video not found")
+	}
+
+	return nil
+}
+
+// GetTrashedBefore returns videos that were moved into the trash before the given time, oldest first
+func (r *VideoRepositoryImpl) GetTrashedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.Video, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
 	opts := options.Find().
 		SetLimit(int64(limit)).
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}})
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list videos: %w", err)
+		return nil, fmt.Errorf("failed to get trashed videos: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -101,6 +338,14 @@ func (r *VideoRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*e
 	return videos, nil
 }
 
+func (r *VideoRepositoryImpl) List(ctx context.Context, filter repositories.VideoFilter, limit int, after *repositories.PageCursor) ([]*entities.Video, *repositories.PageCursor, error) {
+	videos, next, err := r.findPage(ctx, videoFilterConditions(filter), limit, after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list videos: %w", err)
+	}
+	return videos, next, nil
+}
+
 func (r *VideoRepositoryImpl) GetByStatus(ctx context.Context, status entities.VideoStatus) ([]*entities.Video, error) {
 	filter := bson.M{"status": status}
 	cursor, err := r.collection.Find(ctx, filter)
@@ -121,47 +366,242 @@ func (r *VideoRepositoryImpl) GetByStatus(ctx context.Context, status entities.V
 	return videos, nil
 }
 
-func (r *VideoRepositoryImpl) GetByUploadedBy(ctx context.Context, uploadedBy string, limit, offset int) ([]*entities.Video, error) {
-	filter := bson.M{"uploaded_by": uploadedBy}
-	opts := options.Find().
-		SetLimit(int64(limit)).
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+func (r *VideoRepositoryImpl) GetByUploadedBy(ctx context.Context, uploadedBy string, limit int, after *repositories.PageCursor) ([]*entities.Video, *repositories.PageCursor, error) {
+	videos, next, err := r.findPage(ctx, []bson.M{{"uploaded_by": uploadedBy}}, limit, after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get videos by uploader: %w", err)
+	}
+	return videos, next, nil
+}
 
-	cursor, err := r.collection.Find(ctx, filter, opts)
+func (r *VideoRepositoryImpl) Search(ctx context.Context, filter repositories.VideoFilter, limit int, after *repositories.PageCursor) ([]*entities.Video, *repositories.PageCursor, error) {
+	// The text score is only known after matching, so the cursor is applied in a later stage
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchAll(videoFilterConditions(filter))}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	if after != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: afterRankedCursor("score", after)}})
+	}
+	// Rank by the text index's weighted relevance, newest first among equally relevant videos
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: append(bson.D{{Key: "score", Value: -1}}, newestFirst...)}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get videos by uploader: %w", err)
+		return nil, nil, fmt.Errorf("failed to search videos: %w", err)
 	}
 	defer cursor.Close(ctx)
 
 	var videos []*entities.Video
+	var scores []float64
 	for cursor.Next(ctx) {
-		var video entities.Video
-		if err := cursor.Decode(&video); err != nil {
-			return nil, fmt.Errorf("failed to decode video: %w", err)
+		var result struct {
+			entities.Video `bson:",inline"`
+			Score          float64 `bson:"score"`
 		}
-		videos = append(videos, &video)
+		if err := cursor.Decode(&result); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode video: %w", err)
+		}
+		videos = append(videos, &result.Video)
+		scores = append(scores, result.Score)
 	}
 
-	return videos, nil
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	videos, next := videoPage(videos, limit)
+	if next != nil {
+		next.Score = scores[limit-1]
+	}
+	return videos, next, nil
 }
 
-func (r *VideoRepositoryImpl) Search(ctx context.Context, query string, limit, offset int) ([]*entities.Video, error) {
+func (r *VideoRepositoryImpl) Count(ctx context.Context, filter repositories.VideoFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, matchAll(videoFilterConditions(filter)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count videos: %w", err)
+	}
+	return count, nil
+}
+
+func (r *VideoRepositoryImpl) CountByUploadedBySince(ctx context.Context, uploadedBy string, since time.Time) (int64, error) {
 	filter := bson.M{
-		"$or": []bson.M{
-			{"title": bson.M{"$regex": query, "$options": "i"}},
-			{"description": bson.M{"$regex": query, "$options": "i"}},
-		},
+		"uploaded_by": uploadedBy,
+		"created_at":  bson.M{"$gte": since},
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count videos by uploader: %w", err)
 	}
+	return count, nil
+}
+
+func (r *VideoRepositoryImpl) GetStorageUsage(ctx context.Context, uploadedBy string) (*entities.StorageUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"uploaded_by": uploadedBy}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             nil,
+			"originals_bytes": bson.M{"$sum": bson.M{"$add": bson.A{"$size", bson.M{"$sum": "$revisions.size"}}}},
+			"renditions_bytes": bson.M{"$sum": bson.M{"$add": bson.A{
+				bson.M{"$sum": "$formats.size"}, bson.M{"$sum": "$formats.hls_size"},
+				bson.M{"$sum": "$pending_formats.size"}, bson.M{"$sum": "$pending_formats.hls_size"},
+			}}},
+			"thumbnails_bytes": bson.M{"$sum": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$thumbnails_size", 0}}, bson.M{"$ifNull": bson.A{"$pending_thumbnails_size", 0}}}}},
+			"captions_bytes":   bson.M{"$sum": bson.M{"$sum": "$captions.size"}},
+			"video_count":      bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate storage usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	usage := &entities.StorageUsage{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(usage); err != nil {
+			return nil, fmt.Errorf("failed to decode storage usage: %w", err)
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (r *VideoRepositoryImpl) CountByCategory(ctx context.Context, filter repositories.VideoFilter) ([]entities.CategoryCount, error) {
+	conditions := append(videoFilterConditions(filter), bson.M{"category": bson.M{"$exists": true}})
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchAll(conditions)}},
+		{{Key: "$group", Value: bson.M{"_id": "$category", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count videos by category: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	counts := []entities.CategoryCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode category counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *VideoRepositoryImpl) CountByTag(ctx context.Context, filter repositories.VideoFilter, prefix string, limit int) ([]entities.TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchAll(videoFilterConditions(filter))}},
+		{{Key: "$unwind", Value: "$tags"}},
+	}
+	if prefix != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"tags": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count videos by tag: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	counts := []entities.TagCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode tag counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// IncrementViews adds n counted views to a video
+func (r *VideoRepositoryImpl) IncrementViews(ctx context.Context, id primitive.ObjectID, n int64) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"view_count": n}})
+	if err != nil {
+		return fmt.Errorf("failed to update view count: %w", err)
+	}
+	return nil
+}
+
+func (r *VideoRepositoryImpl) IncrementReactions(ctx context.Context, id primitive.ObjectID, delta entities.ReactionCounts) error {
+	update := bson.M{
+		"$inc": bson.M{"like_count": delta.Likes, "dislike_count": delta.Dislikes},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update reaction counts: %w", err)
+	}
+	return nil
+}
 
+func (r *VideoRepositoryImpl) ResetReactions(ctx context.Context, id primitive.ObjectID, expected, counts entities.ReactionCounts) (bool, error) {
+	filter := bson.M{
+		"_id":           id,
+		"like_count":    counterValue(expected.Likes),
+		"dislike_count": counterValue(expected.Dislikes),
+	}
+	update := bson.M{
+		"$set": bson.M{"like_count": counts.Likes, "dislike_count": counts.Dislikes},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to reset reaction counts: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// counterValue matches a counter holding n. Videos nobody reacted to may lack the field, which counts as zero.
+func counterValue(n int64) interface{} {
+	if n == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return n
+}
+
+// toDocument encodes v into a document whose fields can be edited before writing
+func toDocument(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// findPage returns up to limit videos matching the conditions that follow the cursor in newestFirst order
+func (r *VideoRepositoryImpl) findPage(ctx context.Context, conditions []bson.M, limit int, after *repositories.PageCursor) ([]*entities.Video, *repositories.PageCursor, error) {
+	if after != nil {
+		conditions = append(conditions, afterCursor(after))
+	}
+
+	// One extra video tells whether another page follows
 	opts := options.Find().
-		SetLimit(int64(limit)).
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+		SetLimit(int64(limit) + 1).
+		SetSort(newestFirst)
 
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search videos: %w", err)
+		return nil, nil, err
 	}
 	defer cursor.Close(ctx)
 
@@ -169,18 +609,114 @@ func (r *VideoRepositoryImpl) Search(ctx context.Context, query string, limit, o
 	for cursor.Next(ctx) {
 		var video entities.Video
 		if err := cursor.Decode(&video); err != nil {
-			return nil, fmt.Errorf("failed to decode video: %w", err)
+			return nil, nil, fmt.Errorf("failed to decode video: %w", err)
 		}
 		videos = append(videos, &video)
 	}
 
-	return videos, nil
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	videos, next := videoPage(videos, limit)
+	return videos, next, nil
 }
 
-func (r *VideoRepositoryImpl) Count(ctx context.Context) (int64, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		return 0, fmt.Errorf("failed to count videos: %w", err)
+// videoPage trims a result fetched with one extra video to the page size and returns the cursor of the next page, if any
+func videoPage(videos []*entities.Video, limit int) ([]*entities.Video, *repositories.PageCursor) {
+	if len(videos) <= limit {
+		return videos, nil
 	}
-	return count, nil
+	last := videos[limit-1]
+	return videos[:limit], &repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+}
+
+// videoFilterConditions translates a VideoFilter into MongoDB query conditions
+func videoFilterConditions(filter repositories.VideoFilter) []bson.M {
+	var conditions []bson.M
+
+	if filter.Trashed {
+		conditions = append(conditions, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	} else {
+		conditions = append(conditions, bson.M{"deleted_at": nil})
+	}
+
+	if filter.Text != "" {
+		conditions = append(conditions, bson.M{"$text": bson.M{"$search": textSearchTerms(filter.Text)}})
+	}
+
+	if filter.UploadedBy != "" {
+		conditions = append(conditions, bson.M{"uploaded_by": filter.UploadedBy})
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, bson.M{"status": filter.Status})
+	}
+
+	if filter.MinDuration > 0 {
+		conditions = append(conditions, bson.M{"duration": bson.M{"$gte": filter.MinDuration}})
+	}
+	if filter.MaxDuration > 0 {
+		conditions = append(conditions, bson.M{"duration": bson.M{"$lte": filter.MaxDuration}})
+	}
+
+	if filter.UploadedAfter != nil {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$gte": *filter.UploadedAfter}})
+	}
+	if filter.UploadedBefore != nil {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$lt": *filter.UploadedBefore}})
+	}
+
+	if filter.Quality != "" {
+		conditions = append(conditions, bson.M{"formats.quality": filter.Quality})
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, bson.M{"tags": filter.Tag})
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, bson.M{"category": filter.Category})
+	}
+
+	if filter.ChannelIDs != nil {
+		conditions = append(conditions, bson.M{"channel_id": bson.M{"$in": filter.ChannelIDs}})
+	}
+
+	if filter.ListedOnly {
+		// Videos stored before visibility existed have no visibility field and are public
+		listed := bson.M{
+			"visibility": bson.M{"$in": []interface{}{entities.VisibilityPublic, nil}},
+			"$or": []bson.M{
+				{"publish_at": nil},
+				{"publish_at": bson.M{"$lte": time.Now()}},
+			},
+		}
+
+		if filter.Viewer != "" {
+			conditions = append(conditions, bson.M{"$or": []bson.M{listed, {"uploaded_by": filter.Viewer}}})
+		} else {
+			conditions = append(conditions, listed)
+		}
+	}
+
+	return conditions
+}
+
+// textSearchTerms turns user input into plain $text search terms. Quotes and leading
+// minus signs would otherwise be read as phrase and negation operators.
+func textSearchTerms(query string) string {
+	terms := strings.Fields(strings.ReplaceAll(query, `"`, " "))
+	for i, term := range terms {
+		terms[i] = strings.TrimLeft(term, "-")
+	}
+	return strings.Join(terms, " ")
+}
+
+// matchAll combines query conditions into a single filter
+func matchAll(conditions []bson.M) bson.M {
+	if len(conditions) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conditions}
 }
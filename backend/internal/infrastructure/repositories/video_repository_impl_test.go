@@ -0,0 +1,28 @@
+package repositories
+
+import "testing"
+
+func TestTextSearchTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "plain words", query: "go tutorial", want: "go tutorial"},
+		{name: "extra whitespace", query: "  go \t tutorial\n", want: "go tutorial"},
+		{name: "quotes do not start phrases", query: `"go tutorial" basics`, want: "go tutorial basics"},
+		{name: "unbalanced quote", query: `go "tutorial`, want: "go tutorial"},
+		{name: "minus does not negate", query: "go -tutorial --basics", want: "go tutorial basics"},
+		{name: "hyphenated words are kept", query: "live-coding", want: "live-coding"},
+		{name: "empty", query: "", want: ""},
+		{name: "only quotes", query: `""`, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textSearchTerms(tt.query); got != tt.want {
+				t.Errorf("textSearchTerms(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
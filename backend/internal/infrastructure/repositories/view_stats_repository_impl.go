@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ViewStatsRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewViewStatsRepository(db *database.MongoDB) repositories.ViewStatsRepository {
+	return &ViewStatsRepositoryImpl{
+		collection: db.GetCollection("video_view_stats"),
+	}
+}
+
+// Record upserts the day's document with $inc, so concurrent events never lose each other's counts
+func (r *ViewStatsRepositoryImpl) Record(ctx context.Context, videoID primitive.ObjectID, day time.Time, delta entities.ViewStatsDelta) error {
+	inc := bson.M{
+		"views":      delta.Views,
+		"plays":      delta.Plays,
+		"watch_time": delta.WatchTime,
+	}
+	for _, bucket := range delta.Buckets {
+		inc["retention."+strconv.Itoa(bucket)] = 1
+	}
+
+	filter := bson.M{"video_id": videoID, "day": day}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": inc}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to record view stats: %w", err)
+	}
+	return nil
+}
+
+func (r *ViewStatsRepositoryImpl) GetDaily(ctx context.Context, videoID primitive.ObjectID, from, to time.Time) ([]*entities.DailyViewStats, error) {
+	filter := bson.M{
+		"video_id": videoID,
+		"day":      bson.M{"$gte": from, "$lte": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get view stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats []*entities.DailyViewStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode view stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *ViewStatsRepositoryImpl) ListViewsSince(ctx context.Context, from time.Time) ([]*entities.DailyViewStats, error) {
+	filter := bson.M{"day": bson.M{"$gte": from}, "views": bson.M{"$gt": 0}}
+	opts := options.Find().SetProjection(bson.M{"video_id": 1, "day": 1, "views": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent views: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats []*entities.DailyViewStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode view stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *ViewStatsRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID})
+	if err != nil {
+		return fmt.Errorf("failed to delete view stats: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youtube-backend/internal/domain/entities"
+	"youtube-backend/internal/domain/repositories"
+	"youtube-backend/internal/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// recentlyWatchedFirst is the order of watch history listings
+var recentlyWatchedFirst = bson.D{{Key: "watched_at", Value: -1}, {Key: "_id", Value: -1}}
+
+type WatchHistoryRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewWatchHistoryRepository(db *database.MongoDB) repositories.WatchHistoryRepository {
+	return &WatchHistoryRepositoryImpl{
+		collection: db.GetCollection("watch_history"),
+	}
+}
+
+// Save upserts the single entry of the user and video
+func (r *WatchHistoryRepositoryImpl) Save(ctx context.Context, progress *entities.WatchProgress) error {
+	filter := bson.M{"user_id": progress.UserID, "video_id": progress.VideoID}
+	update := bson.M{
+		"$set": bson.M{
+			"position":   progress.Position,
+			"duration":   progress.Duration,
+			"completed":  progress.Completed,
+			"watched_at": progress.WatchedAt,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		return fmt.Errorf("failed to save watch progress: %w", err)
+	}
+	return nil
+}
+
+func (r *WatchHistoryRepositoryImpl) Get(ctx context.Context, userID string, videoID primitive.ObjectID) (*entities.WatchProgress, error) {
+	var progress entities.WatchProgress
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID, "video_id": videoID}).Decode(&progress)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get watch progress: %w", err)
+	}
+	return &progress, nil
+}
+
+func (r *WatchHistoryRepositoryImpl) List(ctx context.Context, userID string, limit int, after *repositories.PageCursor) ([]*entities.WatchProgress, *repositories.PageCursor, error) {
+	conditions := []bson.M{{"user_id": userID}}
+	if after != nil {
+		conditions = append(conditions, afterTimeCursor("watched_at", after))
+	}
+
+	// One extra entry tells whether another page follows
+	opts := options.Find().
+		SetLimit(int64(limit) + 1).
+		SetSort(recentlyWatchedFirst)
+
+	cursor, err := r.collection.Find(ctx, matchAll(conditions), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list watch history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*entities.WatchProgress
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode watch history: %w", err)
+	}
+
+	if len(entries) <= limit {
+		return entries, nil, nil
+	}
+	last := entries[limit-1]
+	return entries[:limit], &repositories.PageCursor{CreatedAt: last.WatchedAt, ID: last.ID}, nil
+}
+
+func (r *WatchHistoryRepositoryImpl) Count(ctx context.Context, userID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count watch history: %w", err)
+	}
+	return count, nil
+}
+
+func (r *WatchHistoryRepositoryImpl) Delete(ctx context.Context, userID string, videoID primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "video_id": videoID})
+	if err != nil {
+		return fmt.Errorf("failed to delete watch progress: %w", err)
+	}
+	return nil
+}
+
+func (r *WatchHistoryRepositoryImpl) DeleteByUserID(ctx context.Context, userID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear watch history: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *WatchHistoryRepositoryImpl) ListRecentByUser(ctx context.Context, since time.Time, perUser int) ([][]primitive.ObjectID, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"watched_at": bson.M{"$gte": since}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "user_id", Value: 1}, {Key: "watched_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "video_ids": bson.M{"$push": "$video_id"}}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "video_ids": bson.M{"$slice": bson.A{"$video_ids", perUser}}}}},
+	}
+	opts := options.Aggregate().SetAllowDiskUse(true)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently watched videos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups [][]primitive.ObjectID
+	for cursor.Next(ctx) {
+		var result struct {
+			VideoIDs []primitive.ObjectID `bson:"video_ids"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode recently watched videos: %w", err)
+		}
+		groups = append(groups, result.VideoIDs)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list recently watched videos: %w", err)
+	}
+	return groups, nil
+}
+
+func (r *WatchHistoryRepositoryImpl) DeleteByVideoID(ctx context.Context, videoID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"video_id": videoID})
+	if err != nil {
+		return fmt.Errorf("failed to delete watch history: %w", err)
+	}
+	return nil
+}
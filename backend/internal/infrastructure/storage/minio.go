@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"time"
@@ -47,7 +46,7 @@ func NewMinIOClient(cfg config.MinIOConfig, logger *zap.Logger) (*MinIOClient, e
 	return minioClient, nil
 }
 
-// initializeBuckets creates necessary buckets and sets up public access policies
+// initializeBuckets creates necessary buckets and removes public access policies
 func (m *MinIOClient) initializeBuckets(ctx context.Context) error {
 	buckets := []string{m.videosBucketName, m.thumbnailsBucketName}
 
@@ -69,81 +68,42 @@ func (m *MinIOClient) initializeBuckets(ctx context.Context) error {
 		}
 	}
 
-	// Set up bucket policies for public access
-	if err := m.setPublicAccessPolicies(ctx); err != nil {
+	// Make sure no objects are readable straight from MinIO
+	if err := m.removeAnonymousAccess(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// setPublicAccessPolicies sets up public read access for specific paths
-func (m *MinIOClient) setPublicAccessPolicies(ctx context.Context) error {
-	// Policy for videos bucket - allow public read access to processed/* and thumbnails/*
-	videosPolicy := map[string]interface{}{
-		"Version": "2012-10-17",
-		"Statement": []map[string]interface{}{
-			{
-				"Effect": "Allow",
-				"Principal": map[string]string{
-					"AWS": "*",
-				},
-				"Action": []string{
-					"s3:GetObject",
-				},
-				"Resource": []string{
-					"arn:aws:s3:::" + m.videosBucketName + "/processed/*",
-					"arn:aws:s3:::" + m.videosBucketName + "/thumbnails/*",
-				},
-			},
-		},
+// removeAnonymousAccess deletes bucket policies that grant anonymous reads. Media is only served
+// through the API, which checks visibility and playback tokens.
+func (m *MinIOClient) removeAnonymousAccess(ctx context.Context) error {
+	buckets := []string{m.videosBucketName}
+	if m.thumbnailsBucketName != m.videosBucketName {
+		buckets = append(buckets, m.thumbnailsBucketName)
 	}
 
-	// Policy for thumbnails bucket - allow public read access to all objects
-	thumbnailsPolicy := map[string]interface{}{
-		"Version": "2012-10-17",
-		"Statement": []map[string]interface{}{
-			{
-				"Effect": "Allow",
-				"Principal": map[string]string{
-					"AWS": "*",
-				},
-				"Action": []string{
-					"s3:GetObject",
-				},
-				"Resource": []string{
-					"arn:aws:s3:::" + m.thumbnailsBucketName + "/*",
-				},
-			},
-		},
-	}
-
-	// Apply policies
-	videosPolicyJSON, err := json.Marshal(videosPolicy)
-	if err != nil {
-		return err
-	}
-
-	thumbnailsPolicyJSON, err := json.Marshal(thumbnailsPolicy)
-	if err != nil {
-		return err
-	}
-
-	// Try to set bucket policies, but don't fail if policies are not supported
-	if err := m.client.SetBucketPolicy(ctx, m.videosBucketName, string(videosPolicyJSON)); err != nil {
-		m.logger.Warn("Failed to set bucket policy (policies may not be supported)",
-			zap.String("bucket", m.videosBucketName),
-			zap.Error(err))
-	} else {
-		m.logger.Info("Set public access policy for bucket", zap.String("bucket", m.videosBucketName))
-	}
+	for _, bucketName := range buckets {
+		policy, err := m.client.GetBucketPolicy(ctx, bucketName)
+		if err != nil {
+			m.logger.Warn("Failed to read bucket policy (policies may not be supported)",
+				zap.String("bucket", bucketName),
+				zap.Error(err))
+			continue
+		}
+		if policy == "" {
+			continue
+		}
 
-	if err := m.client.SetBucketPolicy(ctx, m.thumbnailsBucketName, string(thumbnailsPolicyJSON)); err != nil {
-		m.logger.Warn("Failed to set bucket policy (policies may not be supported)",
-			zap.String("bucket", m.thumbnailsBucketName),
-			zap.Error(err))
-	} else {
-		m.logger.Info("Set public access policy for bucket", zap.String("bucket", m.thumbnailsBucketName))
+		// An empty policy removes the bucket policy
+		if err := m.client.SetBucketPolicy(ctx, bucketName, ""); err != nil {
+			m.logger.Warn("Failed to remove bucket policy",
+				zap.String("bucket", bucketName),
+				zap.Error(err))
+		} else {
+			m.logger.Info("Removed anonymous access policy from bucket", zap.String("bucket", bucketName))
+		}
 	}
 
 	return nil
@@ -185,6 +145,15 @@ func (m *MinIOClient) UploadThumbnail(ctx context.Context, objectName string, re
 	return err
 }
 
+// CopyFile copies an object within the videos bucket
+func (m *MinIOClient) CopyFile(ctx context.Context, srcObjectName, dstObjectName string) error {
+	_, err := m.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: m.videosBucketName, Object: dstObjectName},
+		minio.CopySrcOptions{Bucket: m.videosBucketName, Object: srcObjectName},
+	)
+	return err
+}
+
 // DownloadFile downloads a file from the videos bucket
 func (m *MinIOClient) DownloadFile(ctx context.Context, objectName string) (*minio.Object, error) {
 	return m.client.GetObject(ctx, m.videosBucketName, objectName, minio.GetObjectOptions{})
@@ -231,6 +200,27 @@ func (m *MinIOClient) ListThumbnails(ctx context.Context, prefix string) <-chan
 	})
 }
 
+// ListFileNames returns the names of all objects under a prefix in the videos bucket
+func (m *MinIOClient) ListFileNames(ctx context.Context, prefix string) ([]string, error) {
+	return collectObjectNames(m.ListFiles(ctx, prefix))
+}
+
+// ListThumbnailNames returns the names of all objects under a prefix in the thumbnails bucket
+func (m *MinIOClient) ListThumbnailNames(ctx context.Context, prefix string) ([]string, error) {
+	return collectObjectNames(m.ListThumbnails(ctx, prefix))
+}
+
+func collectObjectNames(objects <-chan minio.ObjectInfo) ([]string, error) {
+	var names []string
+	for object := range objects {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		names = append(names, object.Key)
+	}
+	return names, nil
+}
+
 // GeneratePresignedURL generates a presigned URL for file access from videos bucket
 func (m *MinIOClient) GeneratePresignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
 	url, err := m.client.PresignedGetObject(ctx, m.videosBucketName, objectName, expiry, nil)
@@ -1,19 +1,26 @@
 package http
 
 import (
+	"context"
+
 	"youtube-backend/internal/application/handlers"
+	"youtube-backend/internal/domain/entities"
 	"youtube-backend/internal/domain/services"
+	"youtube-backend/internal/infrastructure/auth"
 	"youtube-backend/internal/infrastructure/database"
+	"youtube-backend/internal/infrastructure/moderation"
 	"youtube-backend/internal/infrastructure/queue"
 	"youtube-backend/internal/infrastructure/repositories"
 	"youtube-backend/internal/infrastructure/storage"
 	"youtube-backend/internal/interfaces/middleware"
+	"youtube-backend/pkg/config"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func SetupRoutes(router *gin.Engine, db *database.MongoDB, redis *queue.RedisClient, minio *storage.MinIOClient, logger *zap.Logger) {
+// SetupRoutes registers the API and starts background tasks, which stop when ctx is cancelled
+func SetupRoutes(ctx context.Context, router *gin.Engine, cfg *config.Config, db *database.MongoDB, redis *queue.RedisClient, minio *storage.MinIOClient, logger *zap.Logger) {
 	// Add middleware
 	router.Use(middleware.CORS())
 	router.Use(middleware.RequestLogger(logger))
@@ -21,18 +28,87 @@ func SetupRoutes(router *gin.Engine, db *database.MongoDB, redis *queue.RedisCli
 	// Initialize repositories
 	videoRepo := repositories.NewVideoRepository(db)
 	jobRepo := repositories.NewJobRepository(db)
-	// userRepo := repositories.NewUserRepository(db) // TODO: Implement user handlers
+	userRepo := repositories.NewUserRepository(db)
+	planRepo := repositories.NewPlanRepository(db)
+	batchRepo := repositories.NewBatchRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	contentKeyRepo := repositories.NewContentKeyRepository(db)
+	playlistRepo := repositories.NewPlaylistRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	reactionRepo := repositories.NewReactionRepository(db)
+	viewStatsRepo := repositories.NewViewStatsRepository(db)
+	historyRepo := repositories.NewWatchHistoryRepository(db)
+	channelRepo := repositories.NewChannelRepository(db)
+	subscriptionRepo := repositories.NewSubscriptionRepository(db)
 
 	// Initialize job publisher
 	jobPublisher := queue.NewJobPublisher(redis)
+	viewTracker := queue.NewViewTracker(redis)
+	recommendationCache := queue.NewRecommendationCache(redis)
 
 	// Initialize services
-	videoService := services.NewVideoService(videoRepo, jobRepo, jobPublisher)
+	quotaService := services.NewQuotaService(userRepo, planRepo, videoRepo, cfg.Quota.DefaultPlan, entities.UploadLimits{
+		MaxFileSize:     cfg.Quota.MaxFileSize,
+		MaxTotalStorage: cfg.Quota.MaxTotalStorage,
+		MaxVideosPerDay: cfg.Quota.MaxVideosPerDay,
+		MaxDuration:     cfg.Quota.MaxDuration,
+	})
+	videoService := services.NewVideoService(videoRepo, jobRepo, contentKeyRepo, playlistRepo, commentRepo, reactionRepo, viewStatsRepo, historyRepo, channelRepo, jobPublisher, minio, quotaService, cfg.HLS.KeyRotationSegments)
 	processingService := services.NewProcessingService(jobRepo, videoRepo)
+	catalogService := services.NewCatalogService(videoRepo)
+	playlistService := services.NewPlaylistService(playlistRepo, videoRepo)
+	commentService := services.NewCommentService(commentRepo, videoRepo, []services.ContentFilter{
+		moderation.NewBlockedWordsFilter(cfg.Comments.BlockedWords),
+	})
+	reactionService := services.NewReactionService(reactionRepo, videoRepo)
+	historyService := services.NewHistoryService(historyRepo, videoRepo, userRepo)
+	channelService := services.NewChannelService(channelRepo, subscriptionRepo, videoRepo, minio)
+	captionService := services.NewCaptionService(videoRepo, minio, quotaService)
+	chapterService := services.NewChapterService(videoRepo)
+	thumbnailService := services.NewThumbnailService(videoRepo, minio, quotaService)
+	recommendationService := services.NewRecommendationService(videoRepo, viewStatsRepo, reactionRepo, historyRepo, recommendationCache, cfg.Recommendations.TrendingWindow, cfg.Recommendations.TrendingHalfLife, cfg.Recommendations.CoWatchWindow)
+	viewService := services.NewViewService(videoRepo, viewStatsRepo, historyService, viewTracker, cfg.Views.DedupWindow, cfg.Views.SessionTTL)
+	batchService := services.NewBatchService(batchRepo, videoRepo, jobRepo)
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, auth.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenTTL), cfg.Auth.RefreshTokenTTL)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo)
+
+	// Content keys can only be delivered when the worker's wrapping secret is known
+	var keyUnwrapper services.ContentKeyUnwrapper
+	if cfg.HLS.ContentKeySecret != "" {
+		wrapper, err := auth.NewContentKeyWrapper(cfg.HLS.ContentKeySecret)
+		if err != nil {
+			logger.Fatal("Failed to initialize content key wrapper", zap.Error(err))
+		}
+		keyUnwrapper = wrapper
+	}
+	playbackService := services.NewPlaybackService(videoRepo, contentKeyRepo, auth.NewPlaybackSigner(cfg.Playback.Secret), keyUnwrapper, cfg.Playback.TokenTTL, cfg.Playback.BindIP, cfg.Playback.BindSession)
+
+	// Start background tasks
+	go runTrashPurge(ctx, videoService, cfg.Trash, logger)
+	go runReactionReconcile(ctx, reactionService, cfg.Reactions, logger)
+	go runTrendingRefresh(ctx, recommendationService, cfg.Recommendations, logger)
+	go runRelatedRefresh(ctx, recommendationService, cfg.Recommendations, logger)
 
 	// Initialize handlers
-	videoHandler := handlers.NewVideoHandler(videoService, minio, logger)
+	videoHandler := handlers.NewVideoHandler(videoService, playbackService, historyService, minio, logger)
+	hlsHandler := handlers.NewHLSHandler(playbackService, minio, logger)
 	jobHandler := handlers.NewJobHandler(processingService, logger)
+	catalogHandler := handlers.NewCatalogHandler(catalogService, logger)
+	playlistHandler := handlers.NewPlaylistHandler(playlistService, logger)
+	commentHandler := handlers.NewCommentHandler(commentService, logger)
+	reactionHandler := handlers.NewReactionHandler(reactionService, logger)
+	viewHandler := handlers.NewViewHandler(viewService, logger)
+	historyHandler := handlers.NewHistoryHandler(historyService, logger)
+	channelHandler := handlers.NewChannelHandler(channelService, minio, logger)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService, logger)
+	captionHandler := handlers.NewCaptionHandler(captionService, playbackService, minio, logger)
+	chapterHandler := handlers.NewChapterHandler(chapterService, playbackService, logger)
+	thumbnailHandler := handlers.NewThumbnailHandler(thumbnailService, logger)
+	userHandler := handlers.NewUserHandler(quotaService, logger)
+	batchHandler := handlers.NewBatchHandler(videoService, batchService, playlistService, minio, logger)
+	authHandler := handlers.NewAuthHandler(authService, logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, logger)
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -44,35 +120,131 @@ func SetupRoutes(router *gin.Engine, db *database.MongoDB, redis *queue.RedisCli
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	v1.Use(middleware.Authenticate(authService, apiKeyService))
 	{
+		// Auth routes
+		authRoutes := v1.Group("/auth")
+		{
+			authRoutes.POST("/login", authHandler.Login)
+			authRoutes.POST("/refresh", authHandler.Refresh)
+			authRoutes.POST("/logout", authHandler.Logout)
+		}
+
 		// Video routes
+		videoRead := middleware.RequireScope(entities.ScopeVideosRead)
+		videoWrite := middleware.RequireScope(entities.ScopeVideosWrite)
+
 		videos := v1.Group("/videos")
 		{
-			videos.POST("/upload", videoHandler.UploadVideo)
-			videos.GET("", videoHandler.GetVideos)
-			videos.GET("/:id", videoHandler.GetVideo)
+			videos.POST("/upload", middleware.RequireAuth(), videoWrite, videoHandler.UploadVideo)
+			videos.GET("", videoRead, videoHandler.GetVideos)
+			videos.GET("/trash", middleware.RequireAuth(), videoRead, videoHandler.GetTrash)
+			videos.GET("/trending", videoRead, recommendationHandler.GetTrending)
+			videos.GET("/:id", videoRead, videoHandler.GetVideo)
+			videos.PATCH("/:id", middleware.RequireAuth(), videoWrite, videoHandler.UpdateVideo)
+			videos.DELETE("/:id", middleware.RequireAuth(), videoWrite, videoHandler.DeleteVideo)
+			videos.POST("/:id/restore", middleware.RequireAuth(), videoWrite, videoHandler.RestoreVideo)
+			videos.PUT("/:id/source", middleware.RequireAuth(), videoWrite, videoHandler.ReplaceSource)
+			videos.GET("/:id/playback", videoRead, videoHandler.GetPlayback)
 			videos.GET("/:id/stream", videoHandler.StreamVideo)
-			videos.GET("/:id/thumbnail", videoHandler.GetThumbnail)
-			videos.POST("/:id/process", videoHandler.ProcessVideo)
+			videos.GET("/:id/hls/master.m3u8", hlsHandler.GetMasterPlaylist)
+			videos.GET("/:id/hls/:quality/:file", hlsHandler.GetRenditionFile)
+			videos.GET("/:id/keys/:keyId", hlsHandler.GetContentKey)
+			videos.GET("/:id/thumbnail", videoRead, videoHandler.GetThumbnail)
+			videos.PUT("/:id/thumbnail", middleware.RequireAuth(), videoWrite, thumbnailHandler.SelectThumbnail)
+			videos.POST("/:id/thumbnail", middleware.RequireAuth(), videoWrite, thumbnailHandler.UploadThumbnail)
+			videos.PUT("/:id/captions/:lang", middleware.RequireAuth(), videoWrite, captionHandler.UploadCaption)
+			videos.GET("/:id/captions/:lang", captionHandler.GetCaption)
+			videos.DELETE("/:id/captions/:lang", middleware.RequireAuth(), videoWrite, captionHandler.DeleteCaption)
+			videos.GET("/:id/captions/:lang/index.m3u8", hlsHandler.GetCaptionPlaylist)
+			videos.GET("/:id/chapters", videoRead, chapterHandler.GetChapters)
+			videos.PUT("/:id/chapters", middleware.RequireAuth(), videoWrite, chapterHandler.SetChapters)
+			videos.GET("/:id/chapters.vtt", chapterHandler.GetChapterTrack)
+			videos.GET("/:id/related", videoRead, recommendationHandler.GetRelated)
+			videos.POST("/:id/views", videoRead, viewHandler.RecordViewEvent)
+			videos.GET("/:id/analytics", middleware.RequireAuth(), videoRead, viewHandler.GetAnalytics)
+			videos.GET("/:id/reaction", videoRead, reactionHandler.GetReaction)
+			videos.PUT("/:id/reaction", middleware.RequireAuth(), videoWrite, reactionHandler.SetReaction)
+			videos.DELETE("/:id/reaction", middleware.RequireAuth(), videoWrite, reactionHandler.RemoveReaction)
+			videos.GET("/:id/comments", videoRead, commentHandler.GetComments)
+			videos.POST("/:id/comments", middleware.RequireAuth(), videoWrite, commentHandler.CreateComment)
+			videos.POST("/:id/process", middleware.RequireAuth(), videoWrite, videoHandler.ProcessVideo)
+		}
+
+		// Catalog routes
+		v1.GET("/categories", videoRead, catalogHandler.GetCategories)
+		v1.GET("/tags", videoRead, catalogHandler.GetTags)
+
+		// Comment routes
+		comments := v1.Group("/comments")
+		{
+			comments.GET("/:id/replies", videoRead, commentHandler.GetReplies)
+			comments.PATCH("/:id", middleware.RequireAuth(), videoWrite, commentHandler.UpdateComment)
+			comments.DELETE("/:id", middleware.RequireAuth(), videoWrite, commentHandler.DeleteComment)
+		}
+
+		// Channel routes
+		channels := v1.Group("/channels")
+		{
+			channels.POST("", middleware.RequireAuth(), videoWrite, channelHandler.CreateChannel)
+			channels.GET("", videoRead, channelHandler.GetChannels)
+			channels.GET("/:id", videoRead, channelHandler.GetChannel)
+			channels.PATCH("/:id", middleware.RequireAuth(), videoWrite, channelHandler.UpdateChannel)
+			channels.PUT("/:id/avatar", middleware.RequireAuth(), videoWrite, channelHandler.UploadAvatar)
+			channels.GET("/:id/avatar", channelHandler.GetAvatar)
+			channels.PUT("/:id/banner", middleware.RequireAuth(), videoWrite, channelHandler.UploadBanner)
+			channels.GET("/:id/banner", channelHandler.GetBanner)
+			channels.POST("/:id/subscription", middleware.RequireAuth(), videoWrite, channelHandler.Subscribe)
+			channels.DELETE("/:id/subscription", middleware.RequireAuth(), videoWrite, channelHandler.Unsubscribe)
+		}
+		v1.GET("/feed/subscriptions", middleware.RequireAuth(), videoRead, channelHandler.GetFeed)
+
+		// Playlist routes
+		playlists := v1.Group("/playlists")
+		{
+			playlists.POST("", middleware.RequireAuth(), videoWrite, playlistHandler.CreatePlaylist)
+			playlists.GET("", videoRead, playlistHandler.GetPlaylists)
+			playlists.GET("/:id", videoRead, playlistHandler.GetPlaylist)
+			playlists.PATCH("/:id", middleware.RequireAuth(), videoWrite, playlistHandler.UpdatePlaylist)
+			playlists.DELETE("/:id", middleware.RequireAuth(), videoWrite, playlistHandler.DeletePlaylist)
+			playlists.POST("/:id/videos", middleware.RequireAuth(), videoWrite, playlistHandler.AddVideo)
+			playlists.PUT("/:id/videos", middleware.RequireAuth(), videoWrite, playlistHandler.ReorderVideos)
+			playlists.DELETE("/:id/videos/:videoId", middleware.RequireAuth(), videoWrite, playlistHandler.RemoveVideo)
+			playlists.GET("/:id/next", videoRead, playlistHandler.GetNextVideo)
+		}
+
+		// Batch upload routes
+		batches := v1.Group("/batches")
+		{
+			batches.POST("", middleware.RequireAuth(), videoWrite, batchHandler.CreateBatch)
+			batches.GET("/:id", middleware.RequireAuth(), videoRead, batchHandler.GetBatch)
 		}
 
 		// Job routes
 		jobs := v1.Group("/jobs")
+		jobs.Use(middleware.RequireAuth(), middleware.RequireScope(entities.ScopeJobsRead))
 		{
 			jobs.GET("/:id", jobHandler.GetJob)
 			jobs.GET("/video/:videoId", jobHandler.GetJobsByVideoID)
 			jobs.GET("/active", jobHandler.GetActiveJobs)
 		}
 
-		// User routes (basic implementation for future use)
+		// User routes
 		users := v1.Group("/users")
 		{
-			users.POST("", func(c *gin.Context) {
-				c.JSON(501, gin.H{"error": "User creation not implemented yet"})
-			})
-			users.GET("/:id", func(c *gin.Context) {
-				c.JSON(501, gin.H{"error": "User retrieval not implemented yet"})
-			})
+			users.POST("", authHandler.Register)
+			users.GET("/me", middleware.RequireAuth(), authHandler.GetCurrentUser)
+			users.POST("/me/api-keys", middleware.RequireSession(), apiKeyHandler.CreateAPIKey)
+			users.GET("/me/api-keys", middleware.RequireSession(), apiKeyHandler.GetAPIKeys)
+			users.DELETE("/me/api-keys/:keyId", middleware.RequireSession(), apiKeyHandler.RevokeAPIKey)
+			users.GET("/me/history", middleware.RequireAuth(), videoRead, historyHandler.GetHistory)
+			users.DELETE("/me/history", middleware.RequireAuth(), videoWrite, historyHandler.ClearHistory)
+			users.DELETE("/me/history/:videoId", middleware.RequireAuth(), videoWrite, historyHandler.RemoveFromHistory)
+			users.PUT("/me/history/settings", middleware.RequireAuth(), videoWrite, historyHandler.UpdateSettings)
+			users.GET("/me/subscriptions", middleware.RequireAuth(), videoRead, channelHandler.GetSubscriptions)
+			users.GET("/:id", authHandler.GetUser)
+			users.GET("/:id/usage", middleware.RequireAuth(), userHandler.GetUsage)
+			users.PUT("/:id/role", middleware.RequireSession(), authHandler.UpdateUserRole)
 		}
 	}
 }
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"youtube-backend/internal/domain/services"
+	"youtube-backend/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// trashPurgeBatchSize bounds how many videos one purge run deletes
+const trashPurgeBatchSize = 100
+
+// runTrashPurge permanently deletes trashed videos once their retention window has passed
+func runTrashPurge(ctx context.Context, videoService *services.VideoService, cfg config.TrashConfig, logger *zap.Logger) {
+	if cfg.PurgeInterval <= 0 {
+		logger.Warn("Trash purge is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := videoService.PurgeTrash(ctx, time.Now().Add(-cfg.Retention), trashPurgeBatchSize)
+		if err != nil {
+			logger.Error("Failed to purge trash", zap.Int("purged", purged), zap.Error(err))
+		} else if purged > 0 {
+			logger.Info("Purged trashed videos", zap.Int("purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reactionReconcileBatchSize bounds how many videos are loaded at once while reconciling
+const reactionReconcileBatchSize = 200
+
+// runReactionReconcile periodically repairs video reaction counters that drifted from the reactions collection
+func runReactionReconcile(ctx context.Context, reactionService *services.ReactionService, cfg config.ReactionsConfig, logger *zap.Logger) {
+	if cfg.ReconcileInterval <= 0 {
+		logger.Warn("Reaction counter reconciliation is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		repaired, err := reactionService.ReconcileCounters(ctx, reactionReconcileBatchSize)
+		if err != nil {
+			logger.Error("Failed to reconcile reaction counters", zap.Int("repaired", repaired), zap.Error(err))
+		} else if repaired > 0 {
+			logger.Warn("Repaired drifted reaction counters", zap.Int("repaired", repaired))
+		}
+	}
+}
+
+// runTrendingRefresh periodically recomputes the trending ranking. The ranking outlives a couple of
+// missed runs, then expires rather than going stale.
+func runTrendingRefresh(ctx context.Context, recommendationService *services.RecommendationService, cfg config.RecommendationsConfig, logger *zap.Logger) {
+	if cfg.TrendingInterval <= 0 {
+		logger.Warn("Trending refresh is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.TrendingInterval)
+	defer ticker.Stop()
+
+	for {
+		ranked, err := recommendationService.RefreshTrending(ctx, 3*cfg.TrendingInterval)
+		if err != nil {
+			logger.Error("Failed to refresh trending videos", zap.Error(err))
+		} else {
+			logger.Debug("Refreshed trending videos", zap.Int("ranked", ranked))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRelatedRefresh periodically recomputes the related videos of every public video. Every backend
+// runs the loop, but only the one that claims the run refreshes.
+func runRelatedRefresh(ctx context.Context, recommendationService *services.RecommendationService, cfg config.RecommendationsConfig, logger *zap.Logger) {
+	if cfg.RelatedInterval <= 0 {
+		logger.Warn("Related videos refresh is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.RelatedInterval)
+	defer ticker.Stop()
+
+	for {
+		// The lease expires shortly before the next run is due, whichever backend ticks first
+		claimed, err := recommendationService.ClaimRelatedRefresh(ctx, cfg.RelatedInterval*9/10)
+		if err != nil {
+			logger.Error("Failed to claim related videos refresh", zap.Error(err))
+		} else if claimed {
+			refreshed, err := recommendationService.RefreshRelated(ctx, 3*cfg.RelatedInterval)
+			if err != nil {
+				logger.Error("Failed to refresh related videos", zap.Int("refreshed", refreshed), zap.Error(err))
+			} else {
+				logger.Info("Refreshed related videos", zap.Int("refreshed", refreshed))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
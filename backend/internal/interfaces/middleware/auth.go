@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"youtube-backend/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate resolves the Authorization header of a request into the current identity.
+// It accepts user access tokens ("Bearer <token>") and API keys ("Bearer vhk_..." or "ApiKey vhk_...").
+// Requests without credentials continue anonymously; invalid credentials are rejected.
+func Authenticate(authService *services.AuthService, apiKeyService *services.APIKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		scheme, credential, found := strings.Cut(header, " ")
+		credential = strings.TrimSpace(credential)
+		validScheme := strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, "ApiKey")
+		if !found || !validScheme || credential == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
+			return
+		}
+
+		var identity *services.Identity
+		var err error
+		if services.IsAPIKey(credential) {
+			identity, err = apiKeyService.Authenticate(c.Request.Context(), credential)
+		} else if strings.EqualFold(scheme, "Bearer") {
+			identity, err = authService.Authenticate(c.Request.Context(), credential)
+		} else {
+			err = services.ErrInvalidToken
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired credentials"})
+			return
+		}
+
+		c.Request = c.Request.WithContext(services.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// RequireAuth rejects anonymous requests
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if services.IdentityFromContext(c.Request.Context()) == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession rejects requests that are not made with a user session, such as API key requests
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity := services.IdentityFromContext(c.Request.Context())
+		if identity == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		if identity.APIKey != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This endpoint requires a user session"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScope rejects API key requests whose key lacks the scope.
+// Anonymous requests and user sessions are left to the other checks.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity := services.IdentityFromContext(c.Request.Context())
+		if identity != nil && !identity.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key is missing the " + scope + " scope"})
+			return
+		}
+		c.Next()
+	}
+}
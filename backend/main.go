@@ -5,11 +5,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"youtube-backend/internal/domain/entities"
 	"youtube-backend/internal/infrastructure/database"
+	"youtube-backend/internal/infrastructure/database/migrations"
 	"youtube-backend/internal/infrastructure/queue"
+	"youtube-backend/internal/infrastructure/repositories"
 	"youtube-backend/internal/infrastructure/storage"
 	httphandlers "youtube-backend/internal/interfaces/http"
 	"youtube-backend/pkg/config"
@@ -26,6 +30,9 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("Invalid configuration", zap.Error(err))
+	}
 
 	log.Info("Starting backend",
 		zap.String("redis_uri", cfg.RedisURI),
@@ -39,6 +46,27 @@ func main() {
 	}
 	defer db.Disconnect()
 
+	// "backend migrate" applies pending migrations and exits,
+	// "backend grant-admin <email>" makes an existing account an admin and exits
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			runMigrations(db, log)
+		case "grant-admin":
+			if len(os.Args) != 3 {
+				log.Fatal("Usage: backend grant-admin <email>")
+			}
+			grantAdmin(db, os.Args[2], log)
+		default:
+			log.Fatal("Unknown command", zap.String("command", os.Args[1]))
+		}
+		return
+	}
+
+	if cfg.MigrateOnStartup {
+		runMigrations(db, log)
+	}
+
 	// Initialize Redis
 	redisClient := queue.NewRedisClient(cfg.RedisURI)
 	defer redisClient.Close()
@@ -55,11 +83,20 @@ func main() {
 	}
 
 	router := gin.New()
+	// Forwarding headers are only believed from configured proxies, so clients cannot choose the
+	// address that view counting and playback tokens see
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES", zap.Error(err))
+	}
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 
+	// Background tasks run until shutdown
+	tasksCtx, stopTasks := context.WithCancel(context.Background())
+	defer stopTasks()
+
 	// Setup routes
-	httphandlers.SetupRoutes(router, db, redisClient, minioClient, log)
+	httphandlers.SetupRoutes(tasksCtx, router, cfg, db, redisClient, minioClient, log)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -84,6 +121,7 @@ func main() {
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopTasks()
 
 	// Give outstanding requests a deadline for completion
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -95,3 +133,37 @@ func main() {
 
 	log.Info("Server exited")
 }
+
+// runMigrations applies pending database migrations, exiting on failure
+func runMigrations(db *database.MongoDB, log *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	applied, err := migrations.Run(ctx, db, log)
+	if err != nil {
+		log.Fatal("Failed to migrate database", zap.Error(err))
+	}
+	log.Info("Database is up to date", zap.Int("applied", applied))
+}
+
+// grantAdmin gives the account with the email the admin role, exiting on failure. It bootstraps the first
+// admin; later role changes go through the API.
+func grantAdmin(db *database.MongoDB, email string, log *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	userRepo := repositories.NewUserRepository(db)
+	user, err := userRepo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		log.Fatal("Failed to look up user", zap.Error(err))
+	}
+	if user == nil {
+		log.Fatal("No account with this email", zap.String("email", email))
+	}
+
+	user.SetRole(entities.RoleAdmin)
+	if err := userRepo.Update(ctx, user); err != nil {
+		log.Fatal("Failed to update user", zap.Error(err))
+	}
+	log.Info("Granted admin role", zap.String("user_id", user.ID.Hex()), zap.String("username", user.Username))
+}
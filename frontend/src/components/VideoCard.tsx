@@ -34,8 +34,8 @@ const VideoCard: React.FC<VideoCardProps> = ({ video, onClick }) => {
   };
 
   const getThumbnailUrl = (): string => {
-    if (video.thumbnails && video.thumbnails.length > 0) {
-      return VideoAPI.getThumbnailUrl(video.thumbnails[0]);
+    if (video.primary_thumbnail || (video.thumbnails && video.thumbnails.length > 0)) {
+      return VideoAPI.getThumbnailUrlViaAPI(video.id, 'medium');
     }
     return ''; // Will trigger fallback
   };
@@ -36,14 +36,14 @@ export const useVideoList = () => {
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState<string | null>(null);
   const [hasMore, setHasMore] = useState(true);
-  const [page, setPage] = useState(1);
+  const [cursor, setCursor] = useState<string | undefined>(undefined);
 
-  const fetchVideos = useCallback(async (pageNum: number = 1, reset: boolean = false) => {
+  const fetchVideos = useCallback(async (after?: string, reset: boolean = false) => {
     setLoading(true);
     setError(null);
 
     try {
-      const response: VideoListResponse = await VideoAPI.getVideos(pageNum, 20);
+      const response: VideoListResponse = await VideoAPI.getVideos(after, 20);
       
       if (reset) {
         setVideos(response.videos);
@@ -51,8 +51,8 @@ export const useVideoList = () => {
         setVideos(prev => [...prev, ...response.videos]);
       }
       
-      setHasMore(response.videos.length === 20);
-      setPage(pageNum);
+      setHasMore(!!response.next_cursor);
+      setCursor(response.next_cursor);
     } catch (err) {
       const apiError = err as ApiError;
       setError(apiError.error);
@@ -63,16 +63,16 @@ export const useVideoList = () => {
 
   const loadMore = useCallback(() => {
     if (!loading && hasMore) {
-      fetchVideos(page + 1, false);
+      fetchVideos(cursor, false);
     }
-  }, [fetchVideos, loading, hasMore, page]);
+  }, [fetchVideos, loading, hasMore, cursor]);
 
   const refresh = useCallback(() => {
-    fetchVideos(1, true);
+    fetchVideos(undefined, true);
   }, [fetchVideos]);
 
   useEffect(() => {
-    fetchVideos(1, true);
+    fetchVideos(undefined, true);
   }, [fetchVideos]);
 
   return {
@@ -161,10 +161,26 @@ export const useVideoPlayer = (video: Video | null) => {
   const [muted, setMuted] = useState(false);
   const [fullscreen, setFullscreen] = useState(false);
 
+  const [playbackToken, setPlaybackToken] = useState<string>('');
+
+  // Stream URLs need a signed, expiring playback token
+  useEffect(() => {
+    if (!video) return;
+    let cancelled = false;
+    VideoAPI.getPlayback(video.id)
+      .then(playback => {
+        if (!cancelled) setPlaybackToken(playback.token);
+      })
+      .catch(err => console.error('Failed to get playback token:', err));
+    return () => {
+      cancelled = true;
+    };
+  }, [video]);
+
   const getStreamUrl = useCallback(() => {
-    if (!video) return '';
-    return VideoAPI.getVideoStreamUrl(video.id, currentQuality);
-  }, [video, currentQuality]);
+    if (!video || !playbackToken) return '';
+    return VideoAPI.getVideoStreamUrl(video.id, currentQuality, playbackToken);
+  }, [video, currentQuality, playbackToken]);
 
   const getAvailableQualities = useCallback(() => {
     if (!video) return ['original'];
@@ -1,10 +1,24 @@
 import axios, { AxiosProgressEvent } from 'axios';
 import {
   Video,
+  Caption,
+  ThumbnailSize,
+  ChaptersResponse,
   VideoListResponse,
   UploadResponse,
+  PlaybackResponse,
   Job,
   JobsResponse,
+  Category,
+  TagCount,
+  Playlist,
+  CommentListResponse,
+  ViewEvent,
+  ViewStartResponse,
+  HistoryResponse,
+  Channel,
+  ReactionType,
+  ReactionSummary,
   UploadProgress,
   ApiError
 } from '../types/video';
@@ -110,10 +124,10 @@ export class VideoAPI {
     return response.data;
   }
 
-  // Get videos list
-  static async getVideos(page: number = 1, limit: number = 20): Promise<VideoListResponse> {
+  // Get videos list; pass the previous page's next_cursor to continue
+  static async getVideos(cursor?: string, limit: number = 20): Promise<VideoListResponse> {
     const response = await api.get('/api/v1/videos', {
-      params: { page, limit },
+      params: { cursor, limit },
     });
     return response.data;
   }
@@ -124,22 +138,40 @@ export class VideoAPI {
     return response.data;
   }
 
-  // Get video stream URL
-  static getVideoStreamUrl(videoId: string, quality: string = 'original'): string {
-    return `${API_BASE_URL}/api/v1/videos/${videoId}/stream?quality=${quality}`;
+  // Get a signed playback token; the backend binds it to a session cookie
+  static async getPlayback(videoId: string): Promise<PlaybackResponse> {
+    const response = await api.get(`/api/v1/videos/${videoId}/playback`, { withCredentials: true });
+    return response.data;
+  }
+
+  // Get video stream URL for a playback token
+  static getVideoStreamUrl(videoId: string, quality: string = 'original', token: string = ''): string {
+    return `${API_BASE_URL}/api/v1/videos/${videoId}/stream?quality=${quality}&token=${encodeURIComponent(token)}`;
   }
 
-  // Get thumbnail URL
-  static getThumbnailUrl(thumbnailPath: string): string {
-    // Use MinIO URL if available, otherwise use backend API
-    const currentConfig = getConfig();
-    const minioUrl = currentConfig.minioUrl || `${API_BASE_URL.replace(':8080', ':9000')}`;
-    return `${minioUrl}/thumbnails/${thumbnailPath}`;
+  // Get thumbnail URL via backend API (fallback); candidate picks one of the video's thumbnails
+  static getThumbnailUrlViaAPI(videoId: string, size: ThumbnailSize = 'large', candidate?: string): string {
+    const query = candidate ? `&candidate=${encodeURIComponent(candidate)}` : '';
+    return `${API_BASE_URL}/api/v1/videos/${videoId}/thumbnail?size=${size}${query}`;
   }
 
-  // Get thumbnail URL via backend API (fallback)
-  static getThumbnailUrlViaAPI(videoId: string): string {
-    return `${API_BASE_URL}/api/v1/videos/${videoId}/thumbnail`;
+  // Select the primary thumbnail of a video; an empty name restores the default
+  static async selectThumbnail(videoId: string, thumbnail: string): Promise<Video> {
+    const response = await api.put(`/api/v1/videos/${videoId}/thumbnail`, { thumbnail });
+    return response.data;
+  }
+
+  // Upload a custom JPEG or PNG thumbnail and select it
+  static async uploadThumbnail(videoId: string, image: File): Promise<Video> {
+    const formData = new FormData();
+    formData.append('image', image);
+
+    const response = await api.post(`/api/v1/videos/${videoId}/thumbnail`, formData, {
+      headers: {
+        'Content-Type': 'multipart/form-data',
+      },
+    });
+    return response.data;
   }
 
   // Trigger video processing
@@ -166,14 +198,151 @@ export class VideoAPI {
     return response.data;
   }
 
+  // Get the category taxonomy with video counts
+  static async getCategories(): Promise<Category[]> {
+    const response = await api.get('/api/v1/categories');
+    return response.data.categories;
+  }
+
+  // Get the most used tags, optionally those starting with prefix
+  static async getTags(prefix: string = '', limit: number = 50): Promise<TagCount[]> {
+    const response = await api.get('/api/v1/tags', {
+      params: { prefix: prefix || undefined, limit },
+    });
+    return response.data.tags;
+  }
+
+  // Get a playlist with its watchable videos
+  static async getPlaylist(playlistId: string): Promise<Playlist> {
+    const response = await api.get(`/api/v1/playlists/${playlistId}`);
+    return response.data;
+  }
+
+  // Get the video to autoplay after the given one; null at the end of the playlist
+  static async getNextPlaylistVideo(playlistId: string, afterVideoId?: string): Promise<Video | null> {
+    const response = await api.get(`/api/v1/playlists/${playlistId}/next`, {
+      params: { after: afterVideoId },
+    });
+    return response.status === 204 ? null : response.data;
+  }
+
+  // Report playback; start returns the view_id that heartbeat and end events carry
+  static async sendViewEvent(
+    videoId: string,
+    event: ViewEvent,
+    position: number,
+    viewId?: string
+  ): Promise<ViewStartResponse | null> {
+    const response = await api.post(`/api/v1/videos/${videoId}/views`, {
+      event,
+      position,
+      view_id: viewId,
+    });
+    return event === 'start' ? response.data : null;
+  }
+
+  // Get the signed-in user's watch history
+  static async getHistory(cursor?: string, limit: number = 20): Promise<HistoryResponse> {
+    const response = await api.get('/api/v1/users/me/history', {
+      params: { cursor, limit },
+    });
+    return response.data;
+  }
+
+  // Get the videos trending right now
+  static async getTrendingVideos(limit: number = 20): Promise<VideoListResponse> {
+    const response = await api.get('/api/v1/videos/trending', { params: { limit } });
+    return response.data;
+  }
+
+  // Get videos related to a video
+  static async getRelatedVideos(videoId: string, limit: number = 10): Promise<VideoListResponse> {
+    const response = await api.get(`/api/v1/videos/${videoId}/related`, { params: { limit } });
+    return response.data;
+  }
+
+  // Upload an SRT or WebVTT caption track in a language, replacing any existing one
+  static async uploadCaption(videoId: string, language: string, file: File, label?: string): Promise<Caption> {
+    const formData = new FormData();
+    formData.append('file', file);
+    if (label) {
+      formData.append('label', label);
+    }
+
+    const response = await api.put(`/api/v1/videos/${videoId}/captions/${language}`, formData, {
+      headers: {
+        'Content-Type': 'multipart/form-data',
+      },
+    });
+    return response.data;
+  }
+
+  // Set the chapters of a video; an empty list falls back to the chapters in the description
+  static async setChapters(
+    videoId: string,
+    chapters: { start: number; title: string }[]
+  ): Promise<ChaptersResponse> {
+    const response = await api.put(`/api/v1/videos/${videoId}/chapters`, { chapters });
+    return response.data;
+  }
+
+  // Get a channel by ID or @handle
+  static async getChannel(idOrHandle: string): Promise<Channel> {
+    const response = await api.get(`/api/v1/channels/${idOrHandle}`);
+    return response.data;
+  }
+
+  // Subscribe to a channel, or unsubscribe with false
+  static async setSubscribed(channelId: string, subscribed: boolean): Promise<Channel> {
+    const url = `/api/v1/channels/${channelId}/subscription`;
+    const response = subscribed ? await api.post(url) : await api.delete(url);
+    return response.data;
+  }
+
+  // Get the latest videos from the signed-in user's subscribed channels
+  static async getSubscriptionFeed(cursor?: string, limit: number = 20): Promise<VideoListResponse> {
+    const response = await api.get('/api/v1/feed/subscriptions', {
+      params: { cursor, limit },
+    });
+    return response.data;
+  }
+
+  // Like or dislike a video, or withdraw the reaction with null
+  static async setReaction(videoId: string, type: ReactionType | null): Promise<ReactionSummary> {
+    const url = `/api/v1/videos/${videoId}/reaction`;
+    const response = type ? await api.put(url, { type }) : await api.delete(url);
+    return response.data;
+  }
+
+  // Get the comment threads of a video
+  static async getComments(
+    videoId: string,
+    sort: 'newest' | 'top' = 'newest',
+    cursor?: string,
+    limit: number = 20
+  ): Promise<CommentListResponse> {
+    const response = await api.get(`/api/v1/videos/${videoId}/comments`, {
+      params: { sort, cursor, limit },
+    });
+    return response.data;
+  }
+
+  // Get the replies of a comment thread
+  static async getCommentReplies(commentId: string, cursor?: string, limit: number = 20): Promise<CommentListResponse> {
+    const response = await api.get(`/api/v1/comments/${commentId}/replies`, {
+      params: { cursor, limit },
+    });
+    return response.data;
+  }
+
   // Search videos
   static async searchVideos(
     query: string,
-    page: number = 1,
+    cursor?: string,
     limit: number = 20
   ): Promise<VideoListResponse> {
     const response = await api.get('/api/v1/videos', {
-      params: { q: query, page, limit },
+      params: { q: query, cursor, limit },
     });
     return response.data;
   }
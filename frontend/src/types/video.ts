@@ -2,17 +2,52 @@ export interface Video {
   id: string;
   title: string;
   description: string;
+  tags: string[];
+  category?: string;
   uploaded_by: string;
+  channel_id?: string;
   original_filename: string;
   duration: number;
   size: number;
   status: VideoStatus;
   formats: VideoFormat[];
   thumbnails: string[];
+  primary_thumbnail?: string;
+  custom_thumbnail?: string;
+  captions?: Caption[];
+  chapters?: Chapter[];
+  chapters_source?: ChapterSource;
+  view_count: number;
+  like_count: number;
+  dislike_count: number;
+  resume_position?: number;
   created_at: string;
   updated_at: string;
 }
 
+export interface Caption {
+  language: string;
+  label: string;
+  cues: number;
+  url: string;
+  uploaded_at: string;
+}
+
+export interface Chapter {
+  start: number;
+  end: number;
+  title: string;
+}
+
+export type ChapterSource = 'manual' | 'description';
+
+export interface ChaptersResponse {
+  chapters: Chapter[];
+  source?: ChapterSource;
+}
+
+export type ThumbnailSize = 'small' | 'medium' | 'large';
+
 export interface VideoFormat {
   quality: string;
   filename: string;
@@ -44,16 +79,120 @@ export interface UploadResponse {
   message: string;
 }
 
+export interface PlaybackResponse {
+  video_id: string;
+  token: string;
+  expires_at: string;
+  stream_urls: Record<string, string>;
+  hls_url?: string;
+  thumbnail_url?: string;
+}
+
 export interface VideoListResponse {
   videos: Video[];
   total: number;
-  page: number;
   limit: number;
+  next_cursor?: string;
+}
+
+export interface Category {
+  slug: string;
+  name: string;
+  video_count: number;
+}
+
+export interface TagCount {
+  tag: string;
+  video_count: number;
+}
+
+export interface Playlist {
+  id: string;
+  owner: string;
+  title: string;
+  description: string;
+  visibility: 'public' | 'unlisted' | 'private';
+  video_ids: string[];
+  video_count: number;
+  version: number;
+  videos?: Video[];
+  created_at: string;
+  updated_at: string;
+}
+
+export type ViewEvent = 'start' | 'heartbeat' | 'end';
+
+export interface ViewStartResponse {
+  view_id: string;
+  counted: boolean;
+}
+
+export interface HistoryEntry {
+  video: Video;
+  position: number;
+  resume_position: number;
+  progress: number;
+  completed: boolean;
+  watched_at: string;
+}
+
+export interface HistoryResponse {
+  entries: HistoryEntry[];
+  paused: boolean;
+  total: number;
+  limit: number;
+  next_cursor?: string;
+}
+
+export interface Channel {
+  id: string;
+  owner: string;
+  handle: string;
+  name: string;
+  description: string;
+  avatar_url?: string;
+  banner_url?: string;
+  subscriber_count: number;
+  subscribed?: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export type ReactionType = 'like' | 'dislike';
+
+export interface ReactionSummary {
+  video_id: string;
+  likes: number;
+  dislikes: number;
+  my_reaction?: ReactionType;
+}
+
+export interface Comment {
+  id: string;
+  video_id: string;
+  parent_id?: string;
+  author_id: string;
+  author_name: string;
+  body: string;
+  status: 'visible' | 'deleted' | 'removed';
+  reply_count: number;
+  edited_at?: string;
+  created_at: string;
+}
+
+export interface CommentListResponse {
+  comments: Comment[];
+  total: number;
+  limit: number;
+  next_cursor?: string;
 }
 
 export interface JobsResponse {
   jobs: Job[];
   count: number;
+  total: number;
+  limit: number;
+  next_cursor?: string;
 }
 
 export interface UploadProgress {
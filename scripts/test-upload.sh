@@ -32,14 +32,30 @@ if [ ! -f "$TEST_VIDEO" ]; then
     fi
 fi
 
+# Register (ignored if the account exists) and log in as the test user
+echo ""
+echo "🔑 Logging in as test user..."
+curl -s -X POST -H "Content-Type: application/json" \
+    -d '{"username":"test-user","email":"test-user@example.com","password":"test-password"}' \
+    "$API_URL/api/v1/users" > /dev/null
+LOGIN_RESPONSE=$(curl -s -X POST -H "Content-Type: application/json" \
+    -d '{"login":"test-user","password":"test-password"}' \
+    "$API_URL/api/v1/auth/login")
+ACCESS_TOKEN=$(echo "$LOGIN_RESPONSE" | grep -o '"access_token":"[^"]*"' | cut -d'"' -f4)
+
+if [ -z "$ACCESS_TOKEN" ]; then
+    echo "❌ Failed to log in: $LOGIN_RESPONSE"
+    exit 1
+fi
+
 # Upload the test video
 echo ""
 echo "⬆️  Uploading test video..."
 UPLOAD_RESPONSE=$(curl -s -X POST \
+    -H "Authorization: Bearer $ACCESS_TOKEN" \
     -F "video=@$TEST_VIDEO" \
     -F "title=Test Video - $(date)" \
     -F "description=Automated test upload" \
-    -F "uploaded_by=test-user" \
     "$API_URL/api/v1/videos/upload")
 
 echo "Upload response: $UPLOAD_RESPONSE"
@@ -67,7 +83,7 @@ for i in {1..30}; do
     echo "Check $i/30..."
     
     # Get jobs for this video
-    JOBS_RESPONSE=$(curl -s "$API_URL/api/v1/jobs/video/$VIDEO_ID")
+    JOBS_RESPONSE=$(curl -s -H "Authorization: Bearer $ACCESS_TOKEN" "$API_URL/api/v1/jobs/video/$VIDEO_ID")
     echo "Jobs status: $JOBS_RESPONSE" | jq '.' || echo "$JOBS_RESPONSE"
     
     # Check if all jobs are completed
@@ -97,6 +113,6 @@ echo ""
 echo "📍 Useful commands:"
 echo "   View all videos: curl $API_URL/api/v1/videos"
 echo "   Stream video: curl $API_URL/api/v1/videos/$VIDEO_ID/stream"
-echo "   View jobs: curl $API_URL/api/v1/jobs/video/$VIDEO_ID"
+echo "   View jobs: curl -H \"Authorization: Bearer \$TOKEN\" $API_URL/api/v1/jobs/video/$VIDEO_ID"
 echo ""
 echo "🌐 Access MinIO console: http://localhost:9001 (minioadmin/minioadmin)" 
\ No newline at end of file
@@ -0,0 +1,264 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	hlsPlaylistName       = "index.m3u8"
+	defaultKeyRotation    = 10
+	contentKeyURIScheme   = "key://"
+	hlsSegmentContentType = "video/mp2t"
+	hlsPlaylistType       = "application/vnd.apple.mpegurl"
+)
+
+// HLSOptions controls how a rendition is packaged for HLS playback
+type HLSOptions struct {
+	Encrypt     bool
+	KeyRotation int // segments encrypted with the same key before a new one is used
+}
+
+// hlsRendition is a packaged rendition after it has been uploaded
+type hlsRendition struct {
+	Playlist string // playlist object name relative to videos/hls/
+	Size     int64  // total size of the playlist and its segments
+}
+
+// packageHLS splits a transcoded MP4 into HLS segments, encrypts them when requested
+// and uploads the playlist and segments next to each other
+func (vp *VideoProcessor) packageHLS(ctx context.Context, videoID, localInputPath, quality string, source SourceRevision, opts HLSOptions) (*hlsRendition, error) {
+	if opts.Encrypt && vp.keyWrapper == nil {
+		return nil, errors.New("encryption requested but CONTENT_KEY_SECRET is not configured")
+	}
+
+	prefix := source.outputPrefix(videoID)
+	localDir, err := os.MkdirTemp(vp.tempDir, "hls_"+prefix+"_"+quality+"_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HLS directory: %w", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-i", localInputPath,
+		"-c", "copy",
+		"-f", "hls",
+		"-hls_time", fmt.Sprint(vp.hlsConfig.SegmentDuration),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(localDir, "seg_%04d.ts"),
+		"-y",
+		filepath.Join(localDir, hlsPlaylistName),
+	)
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("HLS packaging failed: %w", err)
+	}
+
+	segments, err := filepath.Glob(filepath.Join(localDir, "seg_*.ts"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HLS segments: %w", err)
+	}
+	sort.Strings(segments)
+
+	playlist, err := os.ReadFile(filepath.Join(localDir, hlsPlaylistName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HLS playlist: %w", err)
+	}
+
+	if opts.Encrypt {
+		rotation := opts.KeyRotation
+		if rotation <= 0 {
+			rotation = defaultKeyRotation
+		}
+
+		keyIDs, err := vp.encryptSegments(ctx, videoID, source, segments, rotation)
+		if err != nil {
+			return nil, err
+		}
+		playlist = addKeyTags(playlist, keyIDs)
+	}
+
+	objectDir := prefix + "/" + quality + "/"
+	var totalSize int64
+
+	for _, segment := range segments {
+		size, err := vp.uploadLocalFile(ctx, segment, "videos/hls/"+objectDir+filepath.Base(segment), hlsSegmentContentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload HLS segment: %w", err)
+		}
+		totalSize += size
+	}
+
+	// The playlist goes last so it never references segments that are not uploaded yet
+	if err := vp.storageClient.UploadFile(ctx, "videos/hls/"+objectDir+hlsPlaylistName, bytes.NewReader(playlist), int64(len(playlist)), hlsPlaylistType); err != nil {
+		return nil, fmt.Errorf("failed to upload HLS playlist: %w", err)
+	}
+	totalSize += int64(len(playlist))
+
+	vp.logger.Info("HLS rendition packaged",
+		zap.String("video_id", videoID),
+		zap.String("quality", quality),
+		zap.Int("segments", len(segments)),
+		zap.Bool("encrypted", opts.Encrypt))
+
+	return &hlsRendition{Playlist: objectDir + hlsPlaylistName, Size: totalSize}, nil
+}
+
+// encryptSegments encrypts segments in place with AES-128-CBC, switching to a new content
+// key every rotation segments. It returns the key ID used for each segment.
+func (vp *VideoProcessor) encryptSegments(ctx context.Context, videoID string, source SourceRevision, segments []string, rotation int) ([]string, error) {
+	keyIDs := make([]string, len(segments))
+	var block cipher.Block
+
+	for i, segment := range segments {
+		index := i / rotation
+		keyIDs[i] = fmt.Sprintf("%s_k%d", source.outputPrefix(videoID), index)
+
+		if i%rotation == 0 {
+			key, err := vp.contentKey(ctx, keyIDs[i], videoID, source.Revision, index)
+			if err != nil {
+				return nil, err
+			}
+			if block, err = aes.NewCipher(key); err != nil {
+				return nil, fmt.Errorf("failed to create segment cipher: %w", err)
+			}
+		}
+
+		data, err := os.ReadFile(segment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HLS segment: %w", err)
+		}
+
+		// Without an IV attribute players use the media sequence number as the IV
+		iv := make([]byte, aes.BlockSize)
+		binary.BigEndian.PutUint64(iv[8:], uint64(i))
+
+		padded := pkcs7Pad(data, aes.BlockSize)
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(padded, padded)
+
+		if err := os.WriteFile(segment, padded, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write encrypted segment: %w", err)
+		}
+	}
+
+	return keyIDs, nil
+}
+
+// contentKey returns the plaintext content key with the given ID, creating it on first use.
+// Renditions of the same revision share keys, so whichever job gets there first wins.
+func (vp *VideoProcessor) contentKey(ctx context.Context, keyID, videoID string, revision, index int) ([]byte, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate content key: %w", err)
+	}
+
+	wrapped, nonce, err := vp.keyWrapper.Wrap(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap content key: %w", err)
+	}
+
+	storedKey, storedNonce, err := vp.mongoClient.GetOrCreateContentKey(ctx, keyID, videoID, revision, index, wrapped, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store content key: %w", err)
+	}
+
+	key, err = vp.keyWrapper.Unwrap(storedKey, storedNonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap content key: %w", err)
+	}
+	return key, nil
+}
+
+// addKeyTags inserts an EXT-X-KEY tag in front of every segment that starts using a new key
+func addKeyTags(playlist []byte, keyIDs []string) []byte {
+	var out bytes.Buffer
+	segment := 0
+	currentKey := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#EXTINF") && segment < len(keyIDs) {
+			if keyIDs[segment] != currentKey {
+				currentKey = keyIDs[segment]
+				fmt.Fprintf(&out, "#EXT-X-KEY:METHOD=AES-128,URI=\"%s%s\"\n", contentKeyURIScheme, currentKey)
+			}
+			segment++
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes()
+}
+
+// uploadLocalFile uploads a local file and returns its size
+func (vp *VideoProcessor) uploadLocalFile(ctx context.Context, localPath, objectName, contentType string) (int64, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := vp.storageClient.UploadFile(ctx, objectName, file, info.Size(), contentType); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+	return append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
+
+// keyWrapper encrypts content keys with a master key derived from CONTENT_KEY_SECRET
+// so keys read from the database are useless on their own
+type keyWrapper struct {
+	aead cipher.AEAD
+}
+
+func newKeyWrapper(secret string) (*keyWrapper, error) {
+	master := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(master[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &keyWrapper{aead: aead}, nil
+}
+
+func (w *keyWrapper) Wrap(key []byte) ([]byte, []byte, error) {
+	nonce := make([]byte, w.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return w.aead.Seal(nil, nonce, key, nil), nonce, nil
+}
+
+func (w *keyWrapper) Unwrap(wrapped, nonce []byte) ([]byte, error) {
+	return w.aead.Open(nil, nonce, wrapped, nil)
+}
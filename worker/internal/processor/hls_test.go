@@ -0,0 +1,48 @@
+package processor
+
+import "testing"
+
+func TestAddKeyTags(t *testing.T) {
+	const playlist = "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:6.0,\nsegment_001.ts\n#EXTINF:3.5,\nsegment_002.ts\n#EXT-X-ENDLIST\n"
+
+	tests := []struct {
+		name   string
+		keyIDs []string
+		want   string
+	}{
+		{
+			name:   "one key for all segments",
+			keyIDs: []string{"k1", "k1", "k1"},
+			want:   "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k1\"\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:6.0,\nsegment_001.ts\n#EXTINF:3.5,\nsegment_002.ts\n#EXT-X-ENDLIST\n",
+		},
+		{
+			name:   "rotated keys",
+			keyIDs: []string{"k1", "k1", "k2"},
+			want:   "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k1\"\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:6.0,\nsegment_001.ts\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k2\"\n#EXTINF:3.5,\nsegment_002.ts\n#EXT-X-ENDLIST\n",
+		},
+		{
+			name:   "a key per segment",
+			keyIDs: []string{"k1", "k2", "k3"},
+			want:   "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k1\"\n#EXTINF:6.0,\nsegment_000.ts\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k2\"\n#EXTINF:6.0,\nsegment_001.ts\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k3\"\n#EXTINF:3.5,\nsegment_002.ts\n#EXT-X-ENDLIST\n",
+		},
+		{
+			name:   "segments without keys are left alone",
+			keyIDs: []string{"k1"},
+			want:   "#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXT-X-KEY:METHOD=AES-128,URI=\"key://k1\"\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:6.0,\nsegment_001.ts\n#EXTINF:3.5,\nsegment_002.ts\n#EXT-X-ENDLIST\n",
+		},
+		{
+			name:   "no keys",
+			keyIDs: nil,
+			want:   playlist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(addKeyTags([]byte(playlist), tt.keyIDs))
+			if got != tt.want {
+				t.Errorf("addKeyTags() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,38 +5,96 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"youtube-worker/internal/queue"
 	"youtube-worker/internal/storage"
+	"youtube-worker/pkg/config"
 
 	"go.uber.org/zap"
 )
 
+// SourceRevision identifies the source file a job processes and where its output goes
+type SourceRevision struct {
+	Object   string // source object name, empty for jobs published before revisions existed
+	Revision int
+	Pending  bool // renditions belong to a replaced source and are promoted once all are done
+}
+
+// inputPath returns the object name of the source file to process
+func (r SourceRevision) inputPath(videoID, ext string) string {
+	if r.Object != "" {
+		return r.Object
+	}
+	return "videos/original/" + videoID + ext
+}
+
+// outputPrefix returns the prefix of rendition names so revisions never overwrite live files
+func (r SourceRevision) outputPrefix(videoID string) string {
+	if r.Revision > 0 {
+		return fmt.Sprintf("%s_r%d", videoID, r.Revision)
+	}
+	return videoID
+}
+
+// thumbnailCandidates is how many thumbnails are generated for the uploader to choose from
+const thumbnailCandidates = 3
+
+// thumbnailSizes are the widths every thumbnail is stored in, matching the backend's thumbnail sizes
+var thumbnailSizes = []struct {
+	name  string
+	width int
+}{
+	{"large", 1280},
+	{"medium", 640},
+	{"small", 320},
+}
+
 type VideoProcessor struct {
 	storageClient *storage.MinIOClient
 	mongoClient   *queue.MongoClient
 	logger        *zap.Logger
 	tempDir       string
+	hlsConfig     config.HLSConfig
+	keyWrapper    *keyWrapper // nil when no content key secret is configured
 }
 
-func NewVideoProcessor(storageClient *storage.MinIOClient, mongoClient *queue.MongoClient, logger *zap.Logger) *VideoProcessor {
+func NewVideoProcessor(storageClient *storage.MinIOClient, mongoClient *queue.MongoClient, hlsConfig config.HLSConfig, logger *zap.Logger) (*VideoProcessor, error) {
 	tempDir := "/tmp/video-processing"
 	os.MkdirAll(tempDir, 0755)
 
+	if hlsConfig.SegmentDuration <= 0 {
+		hlsConfig.SegmentDuration = 6
+	}
+
+	var wrapper *keyWrapper
+	if hlsConfig.ContentKeySecret != "" {
+		var err error
+		if wrapper, err = newKeyWrapper(hlsConfig.ContentKeySecret); err != nil {
+			return nil, fmt.Errorf("failed to initialize content key wrapper: %w", err)
+		}
+	}
+
 	return &VideoProcessor{
 		storageClient: storageClient,
 		mongoClient:   mongoClient,
 		logger:        logger,
 		tempDir:       tempDir,
-	}
+		hlsConfig:     hlsConfig,
+		keyWrapper:    wrapper,
+	}, nil
 }
 
-func (vp *VideoProcessor) StartJob(ctx context.Context, jobID, workerID string) error {
-	return vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", workerID, 0)
+// StartJob claims a job for the worker. Jobs cancelled because their video was deleted are not claimed.
+func (vp *VideoProcessor) StartJob(ctx context.Context, jobID, workerID string) (bool, error) {
+	return vp.mongoClient.ClaimJob(ctx, jobID, workerID)
 }
 
 func (vp *VideoProcessor) CompleteJob(ctx context.Context, jobID string) error {
@@ -53,15 +111,35 @@ func (vp *VideoProcessor) CompleteJob(ctx context.Context, jobID string) error {
 		return nil // Don't fail the job completion if we can't update video status
 	}
 
-	videoID := job["video_id"].(primitive.ObjectID) // Changed from string back to primitive.ObjectID
+	videoID, err := jobVideoID(job)
+	if err != nil {
+		return err
+	}
+	revision := jobRevision(job)
 
-	// Check if all jobs for this video are completed
-	allCompleted, err := vp.mongoClient.AreAllJobsCompleted(ctx, videoID.Hex())
+	// Check if all jobs for this source revision are completed
+	allCompleted, err := vp.mongoClient.AreAllJobsCompleted(ctx, videoID.Hex(), revision)
 	if err != nil {
 		vp.logger.Error("Failed to check if all jobs completed", zap.Error(err))
 		return nil // Don't fail the job completion if we can't check other jobs
 	}
 
+	// Swap in the renditions of a replaced source once all of them exist
+	if allCompleted && revision > 0 {
+		replaced, err := vp.mongoClient.PromotePendingRevision(ctx, videoID.Hex(), revision)
+		if err != nil {
+			vp.logger.Error("Failed to promote pending revision", zap.Error(err))
+			return nil
+		}
+		if replaced != nil {
+			vp.logger.Info("Replaced source processed and promoted",
+				zap.String("video_id", videoID.Hex()),
+				zap.Int("revision", revision))
+			vp.deleteRenditions(ctx, videoID.Hex(), replaced)
+			return nil
+		}
+	}
+
 	// If all jobs are completed, update video status to completed
 	if allCompleted {
 		err = vp.mongoClient.UpdateVideoStatus(ctx, videoID.Hex(), "ready")
@@ -75,11 +153,98 @@ func (vp *VideoProcessor) CompleteJob(ctx context.Context, jobID string) error {
 	return nil
 }
 
+// deleteRenditions removes the processed files, HLS packages and thumbnails of renditions that are no longer
+// live. Failures are only logged: the promotion already happened and leftovers are removed with the video.
+func (vp *VideoProcessor) deleteRenditions(ctx context.Context, videoID string, renditions *queue.Renditions) {
+	var objects []string
+	for _, format := range renditions.Formats {
+		objects = append(objects, "videos/processed/"+format.Filename)
+		if format.Playlist == "" {
+			continue
+		}
+		segments, err := vp.storageClient.ListFileNames(ctx, "videos/hls/"+path.Dir(format.Playlist)+"/")
+		if err != nil {
+			vp.logger.Warn("Failed to list replaced HLS rendition",
+				zap.String("video_id", videoID),
+				zap.String("playlist", format.Playlist),
+				zap.Error(err))
+			continue
+		}
+		objects = append(objects, segments...)
+	}
+	for _, object := range objects {
+		if err := vp.storageClient.DeleteFile(ctx, object); err != nil {
+			vp.logger.Warn("Failed to delete replaced rendition", zap.String("object", object), zap.Error(err))
+		}
+	}
+
+	for _, thumbnail := range renditions.Thumbnails {
+		for _, size := range thumbnailSizes {
+			object := thumbnailObjectName(thumbnail, size.name)
+			if err := vp.storageClient.DeleteThumbnail(ctx, object); err != nil {
+				vp.logger.Warn("Failed to delete replaced thumbnail", zap.String("object", object), zap.Error(err))
+			}
+		}
+	}
+}
+
 func (vp *VideoProcessor) FailJob(ctx context.Context, jobID, errorMessage string) error {
-	return vp.mongoClient.FailJob(ctx, jobID, errorMessage)
+	if err := vp.mongoClient.FailJob(ctx, jobID, errorMessage); err != nil {
+		return err
+	}
+
+	// Mark the video as failed so it doesn't stay in processing forever
+	job, err := vp.mongoClient.GetJob(ctx, jobID)
+	if err != nil {
+		vp.logger.Error("Failed to get job for video status update", zap.Error(err))
+		return nil
+	}
+
+	videoID, err := jobVideoID(job)
+	if err != nil {
+		return err
+	}
+
+	// A failed replacement keeps the current renditions live
+	if revision := jobRevision(job); revision > 0 {
+		recorded, err := vp.mongoClient.FailPendingRevision(ctx, videoID.Hex(), revision, errorMessage)
+		if err != nil {
+			vp.logger.Error("Failed to record pending revision failure", zap.Error(err))
+		}
+		if recorded {
+			return nil
+		}
+	}
+
+	if err := vp.mongoClient.UpdateVideoStatus(ctx, videoID.Hex(), "failed"); err != nil {
+		vp.logger.Error("Failed to update video status to failed", zap.Error(err))
+	}
+
+	return nil
+}
+
+// jobVideoID extracts the video ID of a job document
+func jobVideoID(job bson.M) (primitive.ObjectID, error) {
+	videoID, ok := job["video_id"].(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, fmt.Errorf("job %v has no valid video_id", job["_id"])
+	}
+	return videoID, nil
+}
+
+// jobRevision extracts the source revision of a job document, defaulting to 0 for older jobs
+func jobRevision(job bson.M) int {
+	switch revision := job["revision"].(type) {
+	case int32:
+		return int(revision)
+	case int64:
+		return int(revision)
+	default:
+		return 0
+	}
 }
 
-func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, quality string) error {
+func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, quality string, maxDuration float64, source SourceRevision, hls HLSOptions) error {
 	vp.logger.Info("Starting video transcoding",
 		zap.String("video_id", videoID),
 		zap.String("quality", quality))
@@ -95,12 +260,12 @@ func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, qu
 	ext := filepath.Ext(originalFilename)
 
 	// Define input and output paths
-	inputPath := "videos/original/" + videoID + ext
-	outputFilename := videoID + "_" + quality + ".mp4"
+	inputPath := source.inputPath(videoID, ext)
+	outputFilename := source.outputPrefix(videoID) + "_" + quality + ".mp4"
 	outputPath := "videos/processed/" + outputFilename
 
 	// Local temporary file paths
-	localInputPath := filepath.Join(vp.tempDir, "input_"+videoID+ext)
+	localInputPath := filepath.Join(vp.tempDir, "input_"+source.outputPrefix(videoID)+ext)
 	localOutputPath := filepath.Join(vp.tempDir, "output_"+outputFilename)
 
 	// Clean up temporary files
@@ -133,6 +298,18 @@ func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, qu
 		return fmt.Errorf("failed to save input file: %w", err)
 	}
 
+	// Transcode jobs run in parallel with the thumbnail job, so each enforces the quota itself
+	// rather than transcoding an over-long video in full
+	if maxDuration > 0 {
+		duration, err := vp.probeDuration(ctx, localInputPath)
+		if err != nil {
+			return fmt.Errorf("failed to probe video duration: %w", err)
+		}
+		if duration > maxDuration {
+			return fmt.Errorf("video duration %.0fs exceeds the allowed maximum of %.0fs", duration, maxDuration)
+		}
+	}
+
 	// Update progress: Processing
 	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 30)
 
@@ -148,6 +325,14 @@ func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, qu
 		return fmt.Errorf("ffmpeg transcoding failed: %w", err)
 	}
 
+	// Update progress: Packaging
+	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 70)
+
+	rendition, err := vp.packageHLS(ctx, videoID, localOutputPath, quality, source, hls)
+	if err != nil {
+		return err
+	}
+
 	// Update progress: Uploading
 	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 80)
 
@@ -171,7 +356,11 @@ func (vp *VideoProcessor) TranscodeVideo(ctx context.Context, videoID, jobID, qu
 	}
 
 	// Update video record with new format
-	err = vp.mongoClient.AddVideoFormat(ctx, videoID, quality, outputFilename, fileInfo.Size())
+	if source.Pending {
+		err = vp.mongoClient.AddPendingVideoFormat(ctx, videoID, source.Revision, quality, outputFilename, rendition.Playlist, fileInfo.Size(), rendition.Size)
+	} else {
+		err = vp.mongoClient.AddVideoFormat(ctx, videoID, quality, outputFilename, rendition.Playlist, fileInfo.Size(), rendition.Size)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update video record: %w", err)
 	}
@@ -190,6 +379,8 @@ func (vp *VideoProcessor) buildFFmpegArgs(inputPath, outputPath, quality string)
 		"-c:v", "libx264",
 		"-preset", "medium",
 		"-crf", "23",
+		// Keyframes on segment boundaries let the MP4 be split into HLS segments without re-encoding
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", vp.hlsConfig.SegmentDuration),
 		"-c:a", "aac",
 		"-b:a", "128k",
 		"-movflags", "+faststart",
@@ -213,7 +404,29 @@ func (vp *VideoProcessor) buildFFmpegArgs(inputPath, outputPath, quality string)
 	return args
 }
 
-func (vp *VideoProcessor) GenerateThumbnail(ctx context.Context, videoID, jobID string) error {
+// probeDuration returns the duration of a media file in seconds using ffprobe
+func (vp *VideoProcessor) probeDuration(ctx context.Context, path string) (float64, error) {
+	cmd := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration: %w", err)
+	}
+
+	return duration, nil
+}
+
+func (vp *VideoProcessor) GenerateThumbnail(ctx context.Context, videoID, jobID string, maxDuration float64, source SourceRevision) error {
 	vp.logger.Info("Starting thumbnail generation", zap.String("video_id", videoID))
 	time.Sleep(5 * time.Second)
 
@@ -227,19 +440,13 @@ func (vp *VideoProcessor) GenerateThumbnail(ctx context.Context, videoID, jobID
 	ext := filepath.Ext(originalFilename)
 
 	// Define paths
-	inputPath := "videos/original/" + videoID + ext
-	thumbnailFilename := videoID + "_thumb.jpg"
-	// Note: thumbnailFilename is used directly as object name in thumbnails bucket
+	inputPath := source.inputPath(videoID, ext)
 
-	// Local temporary file paths
-	localInputPath := filepath.Join(vp.tempDir, "thumb_input_"+videoID+ext)
-	localThumbnailPath := filepath.Join(vp.tempDir, "thumb_"+thumbnailFilename)
+	// Local temporary file path
+	localInputPath := filepath.Join(vp.tempDir, "thumb_input_"+source.outputPrefix(videoID)+ext)
 
 	// Clean up temporary files
-	defer func() {
-		os.Remove(localInputPath)
-		os.Remove(localThumbnailPath)
-	}()
+	defer os.Remove(localInputPath)
 
 	fmt.Println("inputPath", inputPath)
 
@@ -265,54 +472,150 @@ func (vp *VideoProcessor) GenerateThumbnail(ctx context.Context, videoID, jobID
 		return fmt.Errorf("failed to save input file: %w", err)
 	}
 
+	// Probe duration and enforce the uploader's quota
+	duration, err := vp.probeDuration(ctx, localInputPath)
+	if err != nil {
+		return fmt.Errorf("failed to probe video duration: %w", err)
+	}
+
+	// A replaced source keeps the live duration until its renditions are promoted
+	if source.Pending {
+		err = vp.mongoClient.UpdatePendingVideoDuration(ctx, videoID, source.Revision, duration)
+	} else {
+		err = vp.mongoClient.UpdateVideoDuration(ctx, videoID, duration)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update video duration: %w", err)
+	}
+
+	if maxDuration > 0 && duration > maxDuration {
+		return fmt.Errorf("video duration %.0fs exceeds the allowed maximum of %.0fs", duration, maxDuration)
+	}
+
 	// Update progress: Processing
 	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 50)
 
-	// Generate thumbnail using FFmpeg
-	vp.logger.Info("Generating thumbnail with FFmpeg")
+	// Generate thumbnail candidates using FFmpeg
+	vp.logger.Info("Generating thumbnails with FFmpeg")
 
-	cmd := exec.CommandContext(ctx, "ffmpeg",
-		"-i", localInputPath,
-		"-vf", "thumbnail,scale=320:240",
-		"-frames:v", "1",
-		"-q:v", "2",
-		"-y",
-		localThumbnailPath,
-	)
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("thumbnail generation failed: %w", err)
+	var thumbnails []string
+	var totalSize int64
+	for i, timestamp := range thumbnailTimestamps(duration) {
+		objectName := fmt.Sprintf("%s_thumb_%d.jpg", source.outputPrefix(videoID), i+1)
+		size, err := vp.generateThumbnailCandidate(ctx, localInputPath, timestamp, objectName)
+		if err != nil {
+			// A frame that cannot be extracted only costs one candidate
+			vp.logger.Warn("Failed to generate thumbnail candidate",
+				zap.String("video_id", videoID),
+				zap.Float64("timestamp", timestamp),
+				zap.Error(err))
+			continue
+		}
+		thumbnails = append(thumbnails, objectName)
+		totalSize += size
+	}
+	if len(thumbnails) == 0 {
+		return fmt.Errorf("thumbnail generation failed: no candidate could be extracted")
 	}
 
-	// Update progress: Uploading
-	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 80)
+	// Update progress: Recording
+	vp.mongoClient.UpdateJobStatus(ctx, jobID, "processing", "", 90)
 
-	// Upload thumbnail to MinIO
-	thumbnailFile, err := os.Open(localThumbnailPath)
+	// Update video record with thumbnails
+	if source.Pending {
+		err = vp.mongoClient.AddPendingVideoThumbnails(ctx, videoID, source.Revision, thumbnails, totalSize)
+	} else {
+		err = vp.mongoClient.AddVideoThumbnails(ctx, videoID, thumbnails, totalSize)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to open thumbnail: %w", err)
+		return fmt.Errorf("failed to update video record: %w", err)
 	}
-	defer thumbnailFile.Close()
 
-	fileInfo, err := thumbnailFile.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to get thumbnail info: %w", err)
+	vp.logger.Info("Thumbnail generation completed",
+		zap.String("video_id", videoID),
+		zap.Strings("thumbnails", thumbnails))
+
+	return nil
+}
+
+// generateThumbnailCandidate extracts a representative frame near the timestamp and uploads it in every
+// thumbnail size, returning the bytes stored. Frames are scaled to square pixels first, so anamorphic
+// and widescreen sources keep their displayed aspect ratio.
+func (vp *VideoProcessor) generateThumbnailCandidate(ctx context.Context, inputPath string, timestamp float64, objectName string) (int64, error) {
+	filter := fmt.Sprintf("[0:v]scale=trunc(iw*sar/2)*2:ih,setsar=1,thumbnail=25,split=%d", len(thumbnailSizes))
+	for i := range thumbnailSizes {
+		filter += fmt.Sprintf("[s%d]", i)
+	}
+	for i, size := range thumbnailSizes {
+		// Frames narrower than the size are not enlarged
+		filter += fmt.Sprintf(";[s%d]scale=min(%d\\,iw):-2[t%d]", i, size.width, i)
+	}
+
+	args := []string{"-ss", strconv.FormatFloat(timestamp, 'f', 3, 64), "-i", inputPath, "-filter_complex", filter, "-y"}
+	localPaths := make([]string, len(thumbnailSizes))
+	for i, size := range thumbnailSizes {
+		localPaths[i] = filepath.Join(vp.tempDir, "thumb_"+thumbnailObjectName(objectName, size.name))
+		args = append(args, "-map", fmt.Sprintf("[t%d]", i), "-frames:v", "1", "-q:v", "2", localPaths[i])
+	}
+	defer func() {
+		for _, path := range localPaths {
+			os.Remove(path)
+		}
+	}()
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("ffmpeg failed: %w", err)
 	}
 
-	err = vp.storageClient.UploadThumbnail(ctx, thumbnailFilename, thumbnailFile, fileInfo.Size(), "image/jpeg")
+	var total int64
+	for i, size := range thumbnailSizes {
+		uploaded, err := vp.uploadThumbnail(ctx, localPaths[i], thumbnailObjectName(objectName, size.name))
+		if err != nil {
+			return 0, err
+		}
+		total += uploaded
+	}
+	return total, nil
+}
+
+// uploadThumbnail uploads a local JPEG to the thumbnails bucket and returns its size
+func (vp *VideoProcessor) uploadThumbnail(ctx context.Context, localPath, objectName string) (int64, error) {
+	file, err := os.Open(localPath)
 	if err != nil {
-		return fmt.Errorf("failed to upload thumbnail: %w", err)
+		return 0, fmt.Errorf("failed to open thumbnail: %w", err)
 	}
+	defer file.Close()
 
-	// Update video record with thumbnail
-	err = vp.mongoClient.AddVideoThumbnail(ctx, videoID, thumbnailFilename)
+	fileInfo, err := file.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to update video record: %w", err)
+		return 0, fmt.Errorf("failed to get thumbnail info: %w", err)
 	}
 
-	vp.logger.Info("Thumbnail generation completed",
-		zap.String("video_id", videoID),
-		zap.String("thumbnail", thumbnailFilename))
+	if err := vp.storageClient.UploadThumbnail(ctx, objectName, file, fileInfo.Size(), "image/jpeg"); err != nil {
+		return 0, fmt.Errorf("failed to upload thumbnail: %w", err)
+	}
+	return fileInfo.Size(), nil
+}
 
-	return nil
+// thumbnailTimestamps spreads the thumbnail candidates evenly over the video, away from its very start and end
+func thumbnailTimestamps(duration float64) []float64 {
+	if duration < 1 {
+		return []float64{0}
+	}
+	timestamps := make([]float64, thumbnailCandidates)
+	for i := range timestamps {
+		timestamps[i] = duration * float64(i+1) / float64(thumbnailCandidates+1)
+	}
+	return timestamps
+}
+
+// thumbnailObjectName returns the object of a thumbnail in a size, as the backend expects it: the large
+// size is the recorded object, smaller ones add their name before the extension
+func thumbnailObjectName(objectName, size string) string {
+	if size == "large" {
+		return objectName
+	}
+	ext := filepath.Ext(objectName)
+	return strings.TrimSuffix(objectName, ext) + "_" + size + ext
 }
@@ -11,10 +11,11 @@ import (
 )
 
 type MongoClient struct {
-	client           *mongo.Client
-	database         *mongo.Database
-	jobsCollection   *mongo.Collection
-	videosCollection *mongo.Collection
+	client                *mongo.Client
+	database              *mongo.Database
+	jobsCollection        *mongo.Collection
+	videosCollection      *mongo.Collection
+	contentKeysCollection *mongo.Collection
 }
 
 func NewMongoClient(uri string) (*MongoClient, error) {
@@ -34,10 +35,11 @@ func NewMongoClient(uri string) (*MongoClient, error) {
 	database := client.Database("youtube")
 
 	return &MongoClient{
-		client:           client,
-		database:         database,
-		jobsCollection:   database.Collection("jobs"),
-		videosCollection: database.Collection("videos"),
+		client:                client,
+		database:              database,
+		jobsCollection:        database.Collection("jobs"),
+		videosCollection:      database.Collection("videos"),
+		contentKeysCollection: database.Collection("content_keys"),
 	}, nil
 }
 
@@ -75,6 +77,31 @@ func (m *MongoClient) UpdateJobStatus(ctx context.Context, jobID, status, worker
 	return err
 }
 
+// ClaimJob marks a job as processing by the worker unless it was cancelled, and reports whether it was claimed
+func (m *MongoClient) ClaimJob(ctx context.Context, jobID, workerID string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":     "processing",
+			"progress":   0,
+			"worker_id":  workerID,
+			"started_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := m.jobsCollection.UpdateOne(ctx, bson.M{"_id": objID, "status": bson.M{"$ne": "cancelled"}}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 func (m *MongoClient) FailJob(ctx context.Context, jobID, errorMessage string) error {
 	objID, err := primitive.ObjectIDFromHex(jobID)
 	if err != nil {
@@ -94,7 +121,7 @@ func (m *MongoClient) FailJob(ctx context.Context, jobID, errorMessage string) e
 	return err
 }
 
-func (m *MongoClient) AddVideoFormat(ctx context.Context, videoID, quality, filename string, size int64) error {
+func (m *MongoClient) AddVideoFormat(ctx context.Context, videoID, quality, filename, playlist string, size, hlsSize int64) error {
 	objID, err := primitive.ObjectIDFromHex(videoID)
 	if err != nil {
 		return err
@@ -104,6 +131,8 @@ func (m *MongoClient) AddVideoFormat(ctx context.Context, videoID, quality, file
 		"quality":  quality,
 		"filename": filename,
 		"size":     size,
+		"playlist": playlist,
+		"hls_size": hlsSize,
 	}
 
 	update := bson.M{
@@ -115,14 +144,40 @@ func (m *MongoClient) AddVideoFormat(ctx context.Context, videoID, quality, file
 	return err
 }
 
-func (m *MongoClient) AddVideoThumbnail(ctx context.Context, videoID, filename string) error {
+// AddPendingVideoFormat records a rendition of a replaced source without exposing it yet
+func (m *MongoClient) AddPendingVideoFormat(ctx context.Context, videoID string, revision int, quality, filename, playlist string, size, hlsSize int64) error {
 	objID, err := primitive.ObjectIDFromHex(videoID)
 	if err != nil {
 		return err
 	}
 
+	format := bson.M{
+		"quality":  quality,
+		"filename": filename,
+		"size":     size,
+		"playlist": playlist,
+		"hls_size": hlsSize,
+	}
+
 	update := bson.M{
-		"$push": bson.M{"thumbnails": filename},
+		"$push": bson.M{"pending_formats": format},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	_, err = m.videosCollection.UpdateOne(ctx, bson.M{"_id": objID, "pending_revision": revision}, update)
+	return err
+}
+
+// AddVideoThumbnails records generated thumbnails by their large object; size counts the bytes of all their sizes
+func (m *MongoClient) AddVideoThumbnails(ctx context.Context, videoID string, filenames []string, size int64) error {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$push": bson.M{"thumbnails": bson.M{"$each": filenames}},
+		"$inc":  bson.M{"thumbnails_size": size},
 		"$set":  bson.M{"updated_at": time.Now()},
 	}
 
@@ -130,6 +185,124 @@ func (m *MongoClient) AddVideoThumbnail(ctx context.Context, videoID, filename s
 	return err
 }
 
+// AddPendingVideoThumbnails records thumbnails of a replaced source without exposing them yet
+func (m *MongoClient) AddPendingVideoThumbnails(ctx context.Context, videoID string, revision int, filenames []string, size int64) error {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return err
+	}
+
+	// The size is counted once the revision is promoted, replacing the size of the live thumbnails
+	update := bson.M{
+		"$push": bson.M{"pending_thumbnails": bson.M{"$each": filenames}},
+		"$set":  bson.M{"pending_thumbnails_size": size, "updated_at": time.Now()},
+	}
+
+	_, err = m.videosCollection.UpdateOne(ctx, bson.M{"_id": objID, "pending_revision": revision}, update)
+	return err
+}
+
+// RenditionFormat names the objects of a recorded rendition
+type RenditionFormat struct {
+	Filename string `bson:"filename"` // in videos/processed/
+	Playlist string `bson:"playlist"` // HLS playlist relative to videos/hls/
+}
+
+// Renditions lists the renditions and thumbnails of a video
+type Renditions struct {
+	Formats    []RenditionFormat `bson:"formats"`
+	Thumbnails []string          `bson:"thumbnails"`
+}
+
+// PromotePendingRevision atomically swaps the pending renditions of a revision in as the live ones, together
+// with the duration and thumbnail size of the new source. It returns the renditions that were taken out of
+// use so their objects can be deleted, or nil when the revision was not pending.
+func (m *MongoClient) PromotePendingRevision(ctx context.Context, videoID string, revision int) (*Renditions, error) {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"formats":    "$pending_formats",
+			"thumbnails": "$pending_thumbnails",
+			"duration":   bson.M{"$ifNull": bson.A{"$pending_duration", "$duration"}},
+			// The custom thumbnail survives the replacement and stays counted
+			"thumbnails_size": bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$pending_thumbnails_size", 0}},
+				bson.M{"$ifNull": bson.A{"$custom_thumbnail.size", 0}},
+			}},
+			"status":             "ready",
+			"pending_revision":   0,
+			"pending_formats":    nil,
+			"pending_thumbnails": nil,
+			"updated_at":         time.Now(),
+		}}},
+		{{Key: "$unset", Value: bson.A{"pending_duration", "pending_thumbnails_size"}}},
+	}
+
+	var previous struct {
+		Renditions        `bson:",inline"`
+		PendingFormats    []RenditionFormat `bson:"pending_formats"`
+		PendingThumbnails []string          `bson:"pending_thumbnails"`
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.Before).
+		SetProjection(bson.M{"formats": 1, "thumbnails": 1, "pending_formats": 1, "pending_thumbnails": 1})
+	err = m.videosCollection.FindOneAndUpdate(ctx, bson.M{"_id": objID, "pending_revision": revision}, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects still referenced by the new renditions are not replaced
+	current := make(map[string]bool)
+	for _, format := range previous.PendingFormats {
+		current[format.Filename] = true
+		current[format.Playlist] = true
+	}
+	for _, thumbnail := range previous.PendingThumbnails {
+		current[thumbnail] = true
+	}
+
+	replaced := &Renditions{}
+	for _, format := range previous.Formats {
+		if !current[format.Filename] && !current[format.Playlist] {
+			replaced.Formats = append(replaced.Formats, format)
+		}
+	}
+	for _, thumbnail := range previous.Thumbnails {
+		if !current[thumbnail] {
+			replaced.Thumbnails = append(replaced.Thumbnails, thumbnail)
+		}
+	}
+	return replaced, nil
+}
+
+// FailPendingRevision records why reprocessing a replaced source failed, keeping the live renditions
+func (m *MongoClient) FailPendingRevision(ctx context.Context, videoID string, revision int, errorMessage string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return false, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"pending_error": errorMessage,
+			"updated_at":    time.Now(),
+		},
+	}
+
+	result, err := m.videosCollection.UpdateOne(ctx, bson.M{"_id": objID, "pending_revision": revision}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 func (m *MongoClient) GetVideo(ctx context.Context, videoID string) (bson.M, error) {
 	objID, err := primitive.ObjectIDFromHex(videoID)
 	if err != nil {
@@ -141,6 +314,41 @@ func (m *MongoClient) GetVideo(ctx context.Context, videoID string) (bson.M, err
 	return video, err
 }
 
+func (m *MongoClient) UpdateVideoDuration(ctx context.Context, videoID string, duration float64) error {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"duration":   duration,
+			"updated_at": time.Now(),
+		},
+	}
+
+	_, err = m.videosCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return err
+}
+
+// UpdatePendingVideoDuration records the duration of a replaced source; it goes live when the revision is promoted
+func (m *MongoClient) UpdatePendingVideoDuration(ctx context.Context, videoID string, revision int, duration float64) error {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"pending_duration": duration,
+			"updated_at":       time.Now(),
+		},
+	}
+
+	_, err = m.videosCollection.UpdateOne(ctx, bson.M{"_id": objID, "pending_revision": revision}, update)
+	return err
+}
+
 func (m *MongoClient) UpdateVideoStatus(ctx context.Context, videoID, status string) error {
 	objID, err := primitive.ObjectIDFromHex(videoID)
 	if err != nil {
@@ -158,6 +366,40 @@ func (m *MongoClient) UpdateVideoStatus(ctx context.Context, videoID, status str
 	return err
 }
 
+// GetOrCreateContentKey stores a wrapped content key under keyID unless another job already
+// created it, and returns whichever key is stored
+func (m *MongoClient) GetOrCreateContentKey(ctx context.Context, keyID, videoID string, revision, index int, key, nonce []byte) ([]byte, []byte, error) {
+	objID, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = m.contentKeysCollection.InsertOne(ctx, bson.M{
+		"_id":        keyID,
+		"video_id":   objID,
+		"revision":   revision,
+		"index":      index,
+		"key":        key,
+		"nonce":      nonce,
+		"created_at": time.Now(),
+	})
+	if err == nil {
+		return key, nonce, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, nil, err
+	}
+
+	var stored struct {
+		Key   []byte `bson:"key"`
+		Nonce []byte `bson:"nonce"`
+	}
+	if err := m.contentKeysCollection.FindOne(ctx, bson.M{"_id": keyID}).Decode(&stored); err != nil {
+		return nil, nil, err
+	}
+	return stored.Key, stored.Nonce, nil
+}
+
 // GetJob retrieves a job by ID
 func (m *MongoClient) GetJob(ctx context.Context, jobID string) (bson.M, error) {
 	objID, err := primitive.ObjectIDFromHex(jobID)
@@ -170,15 +412,21 @@ func (m *MongoClient) GetJob(ctx context.Context, jobID string) (bson.M, error)
 	return job, err
 }
 
-// AreAllJobsCompleted checks if all jobs for a video are completed
-func (m *MongoClient) AreAllJobsCompleted(ctx context.Context, videoID string) (bool, error) {
+// AreAllJobsCompleted checks if all jobs for a source revision of a video are completed
+func (m *MongoClient) AreAllJobsCompleted(ctx context.Context, videoID string, revision int) (bool, error) {
 	objID, err := primitive.ObjectIDFromHex(videoID)
 	if err != nil {
 		return false, err
 	}
 
+	// Jobs created before revisions existed have no revision field and belong to revision 0
+	revisionFilter := bson.M{"$in": bson.A{revision, nil}}
+	if revision > 0 {
+		revisionFilter = bson.M{"$eq": revision}
+	}
+
 	// Count total jobs for this video
-	totalJobs, err := m.jobsCollection.CountDocuments(ctx, bson.M{"video_id": objID})
+	totalJobs, err := m.jobsCollection.CountDocuments(ctx, bson.M{"video_id": objID, "revision": revisionFilter})
 	if err != nil {
 		return false, err
 	}
@@ -186,6 +434,7 @@ func (m *MongoClient) AreAllJobsCompleted(ctx context.Context, videoID string) (
 	// Count completed jobs for this video
 	completedJobs, err := m.jobsCollection.CountDocuments(ctx, bson.M{
 		"video_id": objID,
+		"revision": revisionFilter,
 		"status":   "completed",
 	})
 	if err != nil {
@@ -105,3 +105,15 @@ func (m *MinIOClient) DeleteFile(ctx context.Context, objectName string) error {
 func (m *MinIOClient) DeleteThumbnail(ctx context.Context, objectName string) error {
 	return m.client.RemoveObject(ctx, m.thumbnailsBucketName, objectName, minio.RemoveObjectOptions{})
 }
+
+// ListFileNames returns the names of all objects under a prefix in the videos bucket
+func (m *MinIOClient) ListFileNames(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	for object := range m.client.ListObjects(ctx, m.videosBucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		names = append(names, object.Key)
+	}
+	return names, nil
+}
@@ -66,7 +66,10 @@ func main() {
 	defer mongoClient.Close()
 
 	// Initialize video processor
-	videoProcessor := processor.NewVideoProcessor(minioClient, mongoClient, log)
+	videoProcessor, err := processor.NewVideoProcessor(minioClient, mongoClient, cfg.HLS, log)
+	if err != nil {
+		log.Fatal("Failed to initialize video processor", zap.Error(err))
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -133,19 +136,27 @@ func processJobs(ctx context.Context, redisClient *queue.RedisClient, processor
 		zap.String("worker_id", workerID))
 
 	// Start job
-	if err := processor.StartJob(ctx, job.ID, workerID); err != nil {
+	started, err := processor.StartJob(ctx, job.ID, workerID)
+	if err != nil {
 		log.Error("Failed to start job", zap.Error(err))
 		return err
 	}
+	if !started {
+		log.Info("Skipping cancelled job", zap.String("job_id", job.ID))
+		return nil
+	}
 
 	// Process based on job type
+	source := sourceRevisionFromPayload(job.Payload)
 	var processErr error
 	switch job.Type {
 	case "transcode":
 		quality := job.Payload["quality"].(string)
-		processErr = processor.TranscodeVideo(ctx, job.VideoID, job.ID, quality)
+		maxDuration, _ := job.Payload["max_duration"].(float64)
+		processErr = processor.TranscodeVideo(ctx, job.VideoID, job.ID, quality, maxDuration, source, hlsOptionsFromPayload(job.Payload))
 	case "thumbnail":
-		processErr = processor.GenerateThumbnail(ctx, job.VideoID, job.ID)
+		maxDuration, _ := job.Payload["max_duration"].(float64)
+		processErr = processor.GenerateThumbnail(ctx, job.VideoID, job.ID, maxDuration, source)
 	default:
 		processErr = fmt.Errorf("unknown job type: %s", job.Type)
 	}
@@ -174,3 +185,24 @@ func processJobs(ctx context.Context, redisClient *queue.RedisClient, processor
 
 	return nil
 }
+
+// sourceRevisionFromPayload reads the source revision a job applies to
+func sourceRevisionFromPayload(payload map[string]interface{}) processor.SourceRevision {
+	source := processor.SourceRevision{}
+	source.Object, _ = payload["source_object"].(string)
+	source.Pending, _ = payload["pending"].(bool)
+	if revision, ok := payload["revision"].(float64); ok {
+		source.Revision = int(revision)
+	}
+	return source
+}
+
+// hlsOptionsFromPayload reads how a transcode job should package its HLS rendition
+func hlsOptionsFromPayload(payload map[string]interface{}) processor.HLSOptions {
+	opts := processor.HLSOptions{}
+	opts.Encrypt, _ = payload["encrypt"].(bool)
+	if rotation, ok := payload["key_rotation"].(float64); ok {
+		opts.KeyRotation = int(rotation)
+	}
+	return opts
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +12,7 @@ type Config struct {
 	MongoURI string
 	RedisURI string
 	MinIO    MinIOConfig
+	HLS      HLSConfig
 }
 
 type MinIOConfig struct {
@@ -21,6 +23,11 @@ type MinIOConfig struct {
 	BucketName string
 }
 
+type HLSConfig struct {
+	SegmentDuration  int    // target segment length in seconds
+	ContentKeySecret string // wraps per-video content keys before they are stored
+}
+
 func Load() *Config {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
@@ -37,6 +44,10 @@ func Load() *Config {
 			UseSSL:     getEnv("MINIO_USE_SSL", "false") == "true",
 			BucketName: getEnv("MINIO_BUCKET_NAME", "videos"),
 		},
+		HLS: HLSConfig{
+			SegmentDuration:  getEnvInt("HLS_SEGMENT_DURATION", 6),
+			ContentKeySecret: getEnv("CONTENT_KEY_SECRET", ""),
+		},
 	}
 }
 
@@ -46,3 +57,17 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value for %s: %q, using default", key, value)
+		return defaultValue
+	}
+	return parsed
+}